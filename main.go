@@ -0,0 +1,27 @@
+/**
+ * パッケージ名: main
+ * ファイル名: main.go
+ * 概要: MonkeyのREPLを起動するエントリポイント
+ */
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+
+	"github.com/MasaruFukazawa/monkey-lang/src/repl"
+)
+
+func main() {
+
+	u, err := user.Current()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Hello %s! This is the Monkey programming language!\n", u.Username)
+	fmt.Println("Feel free to type in commands")
+
+	repl.Start(os.Stdin, os.Stdout)
+}
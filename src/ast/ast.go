@@ -7,6 +7,9 @@ package ast
 
 import (
 	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/MasaruFukazawa/monkey-lang/src/token"
 )
@@ -19,6 +22,14 @@ type Node interface {
 	// デバック用に抽象構文木を文字列にして返す
 	// Nodeを継承する構造体は、String()メソッドを実装しなければならない
 	String() string
+
+	// ノードの開始位置を返す
+	// Nodeを継承する構造体は、Pos()メソッドを実装しなければならない
+	Pos() token.Position
+
+	// ノードの終了位置を返す
+	// Nodeを継承する構造体は、EndPos()メソッドを実装しなければならない
+	EndPos() token.Position
 }
 
 // 抽象構文木の文のインターフェース
@@ -86,6 +97,32 @@ func (ls *LetStatement) String() string {
 	return out.String()
 }
 
+/**
+ * 名前: LetStatement.Pos
+ * 概要:
+ *	LET文の開始位置を返す
+ *  Nodeインターフェースを満たす
+ */
+func (ls *LetStatement) Pos() token.Position {
+	return ls.Token.Pos()
+}
+
+/**
+ * 名前: LetStatement.EndPos
+ * 概要:
+ *	LET文の終了位置を返す
+ *  Valueが存在する場合はValueの終了位置、存在しない場合は変数名の終了位置を返す
+ *  Nodeインターフェースを満たす
+ */
+func (ls *LetStatement) EndPos() token.Position {
+
+	if ls.Value != nil {
+		return ls.Value.EndPos()
+	}
+
+	return ls.Name.EndPos()
+}
+
 // Return文を表すノード
 type ReturnStatement struct {
 	Token       token.Token // 'return' トークン
@@ -100,6 +137,32 @@ type ReturnStatement struct {
  */
 func (rs *ReturnStatement) statementNode() {}
 
+/**
+ * 名前: ReturnStatement.Pos
+ * 概要:
+ *	Return文の開始位置を返す
+ *  Nodeインターフェースを満たす
+ */
+func (rs *ReturnStatement) Pos() token.Position {
+	return rs.Token.Pos()
+}
+
+/**
+ * 名前: ReturnStatement.EndPos
+ * 概要:
+ *	Return文の終了位置を返す
+ *  ReturnValueが存在する場合はReturnValueの終了位置、存在しない場合は自身のトークン位置を返す
+ *  Nodeインターフェースを満たす
+ */
+func (rs *ReturnStatement) EndPos() token.Position {
+
+	if rs.ReturnValue != nil {
+		return rs.ReturnValue.EndPos()
+	}
+
+	return rs.Token.Pos()
+}
+
 // 識別子(変数名・関数名)を表すノード
 type Identifier struct {
 	Token token.Token // token.IDENT トークン
@@ -178,6 +241,32 @@ func (es *ExpressionStatement) String() string {
 	return ""
 }
 
+/**
+ * 名前: ExpressionStatement.Pos
+ * 概要:
+ *	ExpressionStatementの開始位置を返す
+ *  Nodeインターフェースを満たす
+ */
+func (es *ExpressionStatement) Pos() token.Position {
+	return es.Token.Pos()
+}
+
+/**
+ * 名前: ExpressionStatement.EndPos
+ * 概要:
+ *	ExpressionStatementの終了位置を返す
+ *  Expressionが存在する場合はExpressionの終了位置、存在しない場合は自身のトークン位置を返す
+ *  Nodeインターフェースを満たす
+ */
+func (es *ExpressionStatement) EndPos() token.Position {
+
+	if es.Expression != nil {
+		return es.Expression.EndPos()
+	}
+
+	return es.Token.Pos()
+}
+
 /**
  * 名前: Identifier.expressionNode
  * 概要:
@@ -206,6 +295,27 @@ func (i *Identifier) String() string {
 	return i.Value
 }
 
+/**
+ * 名前: Identifier.Pos
+ * 概要:
+ *	識別子(変数名・関数名)の開始位置を返す
+ *	Nodeインターフェースを満たす
+ */
+func (i *Identifier) Pos() token.Position {
+	return i.Token.Pos()
+}
+
+/**
+ * 名前: Identifier.EndPos
+ * 概要:
+ *	識別子(変数名・関数名)の終了位置を返す
+ *	識別子は単一トークンなので開始位置と同じ値を返す
+ *	Nodeインターフェースを満たす
+ */
+func (i *Identifier) EndPos() token.Position {
+	return i.Token.Pos()
+}
+
 // プログラム全体を表すノード
 type Program struct {
 
@@ -241,3 +351,895 @@ func (p *Program) String() string {
 
 	return out.String()
 }
+
+/**
+ * 名前: Program.Pos
+ * 概要:
+ *	プログラム全体の開始位置を返す
+ *  先頭の文の開始位置を返す。文が1つも無い場合はゼロ値を返す
+ */
+func (p *Program) Pos() token.Position {
+
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+
+	return token.Position{}
+}
+
+/**
+ * 名前: Program.EndPos
+ * 概要:
+ *	プログラム全体の終了位置を返す
+ *  末尾の文の終了位置を返す。文が1つも無い場合はゼロ値を返す
+ */
+func (p *Program) EndPos() token.Position {
+
+	if len(p.Statements) > 0 {
+		return p.Statements[len(p.Statements)-1].EndPos()
+	}
+
+	return token.Position{}
+}
+
+/**
+ * 名前: FormatWithPositions
+ * 概要:
+ *	デバッグ用に、ノードとその子ノードをソース上の範囲付きで文字列化する
+ *	String()はデバッグ用の単純な文字列表現のみを担うため、位置情報が
+ *	必要な場面ではこちらを利用する
+ * 引数: node: 文字列化対象のノード
+ * 戻り値: string
+ */
+func FormatWithPositions(node Node) string {
+
+	var out bytes.Buffer
+
+	Walk(&formatVisitor{out: &out}, node)
+
+	return out.String()
+}
+
+// formatVisitor はFormatWithPositionsの巡回処理を担うVisitor
+// Walkに自身の巡回を委ねることで、子ノードの列挙をWalkの実装と二重管理しない
+// (Walkの対象ノードが増えても、このVisitorを変更する必要が無い)
+type formatVisitor struct {
+	out   *bytes.Buffer
+	depth int
+}
+
+/**
+ * 名前: formatVisitor.Visit
+ * 概要:
+ *	現在のノードの種類・位置範囲・String()表現を1行書き出し、
+ *	子ノード用に depth を1つ深くしたVisitorを返す
+ *  Visitorインターフェースを満たす
+ */
+func (f *formatVisitor) Visit(node Node) Visitor {
+
+	if node == nil {
+		return nil
+	}
+
+	for i := 0; i < f.depth; i++ {
+		f.out.WriteString("  ")
+	}
+
+	start, end := node.Pos(), node.EndPos()
+	fmt.Fprintf(f.out, "%T@%s:%d:%d-%d:%d: %s\n",
+		node, start.Filename, start.Line, start.Column, end.Line, end.Column, node.String())
+
+	return &formatVisitor{out: f.out, depth: f.depth + 1}
+}
+
+// 文字列リテラルを表すノード
+type StringLiteral struct {
+	Token token.Token // token.STRING トークン
+	Value string      // 文字列の値
+}
+
+func (sl *StringLiteral) expressionNode() {}
+
+/**
+ * 名前: StringLiteral.TokenLiteral
+ * 概要:
+ *	文字列リテラルのトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (sl *StringLiteral) TokenLiteral() string {
+	return sl.Token.Literal
+}
+
+/**
+ * 名前: StringLiteral.String
+ * 概要:
+ *	文字列リテラルをMonkeyソースとして妥当な、引用符付きの表現で返す
+ *  Nodeインターフェースを満たす
+ */
+func (sl *StringLiteral) String() string {
+	return strconv.Quote(sl.Value)
+}
+
+/**
+ * 名前: StringLiteral.Pos
+ * 概要: 文字列リテラルの開始位置を返す
+ */
+func (sl *StringLiteral) Pos() token.Position {
+	return sl.Token.Pos()
+}
+
+/**
+ * 名前: StringLiteral.EndPos
+ * 概要: 文字列リテラルは単一トークンなので開始位置と同じ値を返す
+ */
+func (sl *StringLiteral) EndPos() token.Position {
+	return sl.Token.Pos()
+}
+
+// 配列リテラルを表すノード
+type ArrayLiteral struct {
+	Token    token.Token  // token.LBRACKET トークン
+	Elements []Expression // 配列の要素
+}
+
+func (al *ArrayLiteral) expressionNode() {}
+
+/**
+ * 名前: ArrayLiteral.TokenLiteral
+ * 概要:
+ *	配列リテラルのトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (al *ArrayLiteral) TokenLiteral() string {
+	return al.Token.Literal
+}
+
+/**
+ * 名前: ArrayLiteral.String
+ * 概要:
+ *	配列リテラルを "[要素1, 要素2, ...]" の形式の文字列にして返す
+ *  Nodeインターフェースを満たす
+ */
+func (al *ArrayLiteral) String() string {
+
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, el := range al.Elements {
+		elements = append(elements, el.String())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+/**
+ * 名前: ArrayLiteral.Pos
+ * 概要: 配列リテラルの開始位置(`[`トークン)を返す
+ */
+func (al *ArrayLiteral) Pos() token.Position {
+	return al.Token.Pos()
+}
+
+/**
+ * 名前: ArrayLiteral.EndPos
+ * 概要: 配列リテラルの終了位置を返す
+ *	末尾の要素の終了位置を返す。要素が1つも無い場合は開始位置を返す
+ */
+func (al *ArrayLiteral) EndPos() token.Position {
+
+	if len(al.Elements) > 0 {
+		return al.Elements[len(al.Elements)-1].EndPos()
+	}
+
+	return al.Token.Pos()
+}
+
+// 添字式 (例: arr[0]) を表すノード
+type IndexExpression struct {
+	Token token.Token // token.LBRACKET トークン
+	Left  Expression  // 添字でアクセスされる式
+	Index Expression  // 添字
+}
+
+func (ie *IndexExpression) expressionNode() {}
+
+/**
+ * 名前: IndexExpression.TokenLiteral
+ * 概要:
+ *	添字式のトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (ie *IndexExpression) TokenLiteral() string {
+	return ie.Token.Literal
+}
+
+/**
+ * 名前: IndexExpression.String
+ * 概要:
+ *	添字式を "(左辺[添字])" の形式の文字列にして返す
+ *  Nodeインターフェースを満たす
+ */
+func (ie *IndexExpression) String() string {
+
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ie.Left.String())
+	out.WriteString("[")
+	out.WriteString(ie.Index.String())
+	out.WriteString("])")
+
+	return out.String()
+}
+
+/**
+ * 名前: IndexExpression.Pos
+ * 概要: 添字式の開始位置を返す (左辺の開始位置)
+ */
+func (ie *IndexExpression) Pos() token.Position {
+	return ie.Left.Pos()
+}
+
+/**
+ * 名前: IndexExpression.EndPos
+ * 概要: 添字式の終了位置を返す (添字の終了位置)
+ */
+func (ie *IndexExpression) EndPos() token.Position {
+	return ie.Index.EndPos()
+}
+
+// ハッシュリテラルを表すノード
+type HashLiteral struct {
+	Token token.Token               // token.LBRACE トークン
+	Pairs map[Expression]Expression // キーと値の組
+}
+
+func (hl *HashLiteral) expressionNode() {}
+
+/**
+ * 名前: HashLiteral.TokenLiteral
+ * 概要:
+ *	ハッシュリテラルのトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (hl *HashLiteral) TokenLiteral() string {
+	return hl.Token.Literal
+}
+
+/**
+ * 名前: HashLiteral.String
+ * 概要:
+ *	ハッシュリテラルを "{キー1: 値1, キー2: 値2, ...}" の形式の文字列にして返す
+ *  Nodeインターフェースを満たす
+ */
+func (hl *HashLiteral) String() string {
+
+	var out bytes.Buffer
+
+	pairs := []string{}
+	for key, value := range hl.Pairs {
+		pairs = append(pairs, key.String()+":"+value.String())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+/**
+ * 名前: HashLiteral.Pos
+ * 概要: ハッシュリテラルの開始位置(`{`トークン)を返す
+ */
+func (hl *HashLiteral) Pos() token.Position {
+	return hl.Token.Pos()
+}
+
+/**
+ * 名前: HashLiteral.EndPos
+ * 概要: ハッシュリテラルの終了位置を返す
+ *	Pairsの反復順序は不定なので、自身のトークン位置を終了位置として返す
+ */
+func (hl *HashLiteral) EndPos() token.Position {
+	return hl.Token.Pos()
+}
+
+// Walkによる抽象構文木の巡回を行うためのインターフェース
+// go/ast.Visitorに倣い、子ノードに潜る前にVisitが呼ばれる
+// 戻り値のwがnilの場合、そのノード以下の子ノードは巡回されない
+type Visitor interface {
+	Visit(node Node) (w Visitor)
+}
+
+/**
+ * 名前: Walk
+ * 処理: ノードvisitorに従って抽象構文木を再帰的に巡回する
+ *       v.Visit(node)がnilを返した場合、nodeの子ノードは巡回しない
+ * 引数: v: 巡回に使用するVisitor, node: 巡回の起点となるノード
+ */
+func Walk(v Visitor, node Node) {
+
+	if node == nil {
+		return
+	}
+
+	v = v.Visit(node)
+	if v == nil {
+		return
+	}
+
+	switch n := node.(type) {
+	case *Program:
+		for _, s := range n.Statements {
+			Walk(v, s)
+		}
+	case *LetStatement:
+		if n.Name != nil {
+			Walk(v, n.Name)
+		}
+		if n.Value != nil {
+			Walk(v, n.Value)
+		}
+	case *ReturnStatement:
+		if n.ReturnValue != nil {
+			Walk(v, n.ReturnValue)
+		}
+	case *ExpressionStatement:
+		if n.Expression != nil {
+			Walk(v, n.Expression)
+		}
+	case *ArrayLiteral:
+		for _, el := range n.Elements {
+			Walk(v, el)
+		}
+	case *IndexExpression:
+		Walk(v, n.Left)
+		Walk(v, n.Index)
+	case *HashLiteral:
+		for key, value := range n.Pairs {
+			Walk(v, key)
+			Walk(v, value)
+		}
+	case *PrefixExpression:
+		Walk(v, n.Right)
+	case *InfixExpression:
+		Walk(v, n.Left)
+		Walk(v, n.Right)
+	case *BlockStatement:
+		for _, s := range n.Statements {
+			Walk(v, s)
+		}
+	case *IfExpression:
+		Walk(v, n.Condition)
+		Walk(v, n.Consequence)
+		if n.Alternative != nil {
+			Walk(v, n.Alternative)
+		}
+	case *FunctionLiteral:
+		for _, p := range n.Parameters {
+			Walk(v, p)
+		}
+		Walk(v, n.Body)
+	case *CallExpression:
+		Walk(v, n.Function)
+		for _, a := range n.Arguments {
+			Walk(v, a)
+		}
+
+	// 子ノードを持たないノードは何もしない
+	case *Identifier, *StringLiteral, *IntegerLiteral, *Boolean:
+	}
+}
+
+// inspector はInspectをWalkの上に実装するためだけのVisitor
+type inspector func(Node) bool
+
+func (f inspector) Visit(node Node) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+/**
+ * 名前: Inspect
+ * 処理: Walkの簡易ラッパー。fnがfalseを返したノードの子孫は巡回しない
+ * 引数: node: 巡回の起点となるノード, fn: 各ノードに対して呼び出される関数
+ */
+func Inspect(node Node, fn func(Node) bool) {
+	Walk(inspector(fn), node)
+}
+
+// 整数リテラルを表すノード
+type IntegerLiteral struct {
+	Token token.Token // token.INT トークン
+	Value int64       // 整数の値
+}
+
+/**
+ * 名前: IntegerLiteral.expressionNode
+ * 概要:
+ *	整数リテラルをExpressionとして扱うためのマーカーメソッド
+ *  Expressionインターフェースを満たす
+ */
+func (il *IntegerLiteral) expressionNode() {}
+
+/**
+ * 名前: IntegerLiteral.TokenLiteral
+ * 概要:
+ *	整数リテラルのトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (il *IntegerLiteral) TokenLiteral() string {
+	return il.Token.Literal
+}
+
+/**
+ * 名前: IntegerLiteral.String
+ * 概要:
+ *	整数リテラルの値を返す
+ *  Nodeインターフェースを満たす
+ */
+func (il *IntegerLiteral) String() string {
+	return il.Token.Literal
+}
+
+/**
+ * 名前: IntegerLiteral.Pos
+ * 概要: 整数リテラルの開始位置を返す
+ */
+func (il *IntegerLiteral) Pos() token.Position {
+	return il.Token.Pos()
+}
+
+/**
+ * 名前: IntegerLiteral.EndPos
+ * 概要: 整数リテラルは単一トークンなので開始位置と同じ値を返す
+ */
+func (il *IntegerLiteral) EndPos() token.Position {
+	return il.Token.Pos()
+}
+
+// 真偽値リテラルを表すノード
+type Boolean struct {
+	Token token.Token // token.TRUE または token.FALSE トークン
+	Value bool        // 真偽値
+}
+
+/**
+ * 名前: Boolean.expressionNode
+ * 概要:
+ *	真偽値リテラルをExpressionとして扱うためのマーカーメソッド
+ *  Expressionインターフェースを満たす
+ */
+func (b *Boolean) expressionNode() {}
+
+/**
+ * 名前: Boolean.TokenLiteral
+ * 概要:
+ *	真偽値リテラルのトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (b *Boolean) TokenLiteral() string {
+	return b.Token.Literal
+}
+
+/**
+ * 名前: Boolean.String
+ * 概要:
+ *	真偽値リテラルの値を返す
+ *  Nodeインターフェースを満たす
+ */
+func (b *Boolean) String() string {
+	return b.Token.Literal
+}
+
+/**
+ * 名前: Boolean.Pos
+ * 概要: 真偽値リテラルの開始位置を返す
+ */
+func (b *Boolean) Pos() token.Position {
+	return b.Token.Pos()
+}
+
+/**
+ * 名前: Boolean.EndPos
+ * 概要: 真偽値リテラルは単一トークンなので開始位置と同じ値を返す
+ */
+func (b *Boolean) EndPos() token.Position {
+	return b.Token.Pos()
+}
+
+// 前置式 (例: !x, -5) を表すノード
+type PrefixExpression struct {
+	Token    token.Token // 前置演算子トークン (例: '!')
+	Operator string      // 前置演算子
+	Right    Expression  // 演算対象の式
+}
+
+/**
+ * 名前: PrefixExpression.expressionNode
+ * 概要:
+ *	前置式をExpressionとして扱うためのマーカーメソッド
+ *  Expressionインターフェースを満たす
+ */
+func (pe *PrefixExpression) expressionNode() {}
+
+/**
+ * 名前: PrefixExpression.TokenLiteral
+ * 概要:
+ *	前置式のトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (pe *PrefixExpression) TokenLiteral() string {
+	return pe.Token.Literal
+}
+
+/**
+ * 名前: PrefixExpression.String
+ * 概要:
+ *	前置式を "(演算子 演算対象)" の形式の文字列にして返す
+ *  Nodeインターフェースを満たす
+ */
+func (pe *PrefixExpression) String() string {
+
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(pe.Operator)
+	out.WriteString(pe.Right.String())
+	out.WriteString(")")
+
+	return out.String()
+}
+
+/**
+ * 名前: PrefixExpression.Pos
+ * 概要: 前置式の開始位置(演算子トークン)を返す
+ */
+func (pe *PrefixExpression) Pos() token.Position {
+	return pe.Token.Pos()
+}
+
+/**
+ * 名前: PrefixExpression.EndPos
+ * 概要: 前置式の終了位置を返す (演算対象の終了位置)
+ */
+func (pe *PrefixExpression) EndPos() token.Position {
+	return pe.Right.EndPos()
+}
+
+// 中置式 (例: x + y) を表すノード
+type InfixExpression struct {
+	Token    token.Token // 中置演算子トークン (例: '+')
+	Left     Expression  // 左辺の式
+	Operator string      // 中置演算子
+	Right    Expression  // 右辺の式
+}
+
+/**
+ * 名前: InfixExpression.expressionNode
+ * 概要:
+ *	中置式をExpressionとして扱うためのマーカーメソッド
+ *  Expressionインターフェースを満たす
+ */
+func (ie *InfixExpression) expressionNode() {}
+
+/**
+ * 名前: InfixExpression.TokenLiteral
+ * 概要:
+ *	中置式のトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (ie *InfixExpression) TokenLiteral() string {
+	return ie.Token.Literal
+}
+
+/**
+ * 名前: InfixExpression.String
+ * 概要:
+ *	中置式を "(左辺 演算子 右辺)" の形式の文字列にして返す
+ *  Nodeインターフェースを満たす
+ */
+func (ie *InfixExpression) String() string {
+
+	var out bytes.Buffer
+
+	out.WriteString("(")
+	out.WriteString(ie.Left.String())
+	out.WriteString(" " + ie.Operator + " ")
+	out.WriteString(ie.Right.String())
+	out.WriteString(")")
+
+	return out.String()
+}
+
+/**
+ * 名前: InfixExpression.Pos
+ * 概要: 中置式の開始位置を返す (左辺の開始位置)
+ */
+func (ie *InfixExpression) Pos() token.Position {
+	return ie.Left.Pos()
+}
+
+/**
+ * 名前: InfixExpression.EndPos
+ * 概要: 中置式の終了位置を返す (右辺の終了位置)
+ */
+func (ie *InfixExpression) EndPos() token.Position {
+	return ie.Right.EndPos()
+}
+
+// ブロック文 (例: { ... }) を表すノード
+type BlockStatement struct {
+	Token      token.Token // '{' トークン
+	Statements []Statement // ブロック内の文の配列
+}
+
+/**
+ * 名前: BlockStatement.statementNode
+ * 概要:
+ *	ブロック文をStatementとして扱うためのマーカーメソッド
+ *  Statementインターフェースを満たす
+ */
+func (bs *BlockStatement) statementNode() {}
+
+/**
+ * 名前: BlockStatement.TokenLiteral
+ * 概要:
+ *	ブロック文のトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (bs *BlockStatement) TokenLiteral() string {
+	return bs.Token.Literal
+}
+
+/**
+ * 名前: BlockStatement.String
+ * 概要:
+ *	ブロック内の文を連結した文字列を返す
+ *  Nodeインターフェースを満たす
+ */
+func (bs *BlockStatement) String() string {
+
+	var out bytes.Buffer
+
+	for _, s := range bs.Statements {
+		out.WriteString(s.String())
+	}
+
+	return out.String()
+}
+
+/**
+ * 名前: BlockStatement.Pos
+ * 概要: ブロック文の開始位置('{'トークン)を返す
+ */
+func (bs *BlockStatement) Pos() token.Position {
+	return bs.Token.Pos()
+}
+
+/**
+ * 名前: BlockStatement.EndPos
+ * 概要: ブロック文の終了位置を返す
+ *	末尾の文の終了位置を返す。文が1つも無い場合は開始位置を返す
+ */
+func (bs *BlockStatement) EndPos() token.Position {
+
+	if len(bs.Statements) > 0 {
+		return bs.Statements[len(bs.Statements)-1].EndPos()
+	}
+
+	return bs.Token.Pos()
+}
+
+// if式 (例: if (x) { ... } else { ... }) を表すノード
+type IfExpression struct {
+	Token       token.Token     // 'if' トークン
+	Condition   Expression      // 条件式
+	Consequence *BlockStatement // 条件が真の場合に評価するブロック
+	Alternative *BlockStatement // 条件が偽の場合に評価するブロック (else節が無い場合はnil)
+}
+
+/**
+ * 名前: IfExpression.expressionNode
+ * 概要:
+ *	if式をExpressionとして扱うためのマーカーメソッド
+ *  Expressionインターフェースを満たす
+ */
+func (ie *IfExpression) expressionNode() {}
+
+/**
+ * 名前: IfExpression.TokenLiteral
+ * 概要:
+ *	if式のトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (ie *IfExpression) TokenLiteral() string {
+	return ie.Token.Literal
+}
+
+/**
+ * 名前: IfExpression.String
+ * 概要:
+ *	if式を "if条件 結果節 else 代替節" の形式の文字列にして返す
+ *  Nodeインターフェースを満たす
+ */
+func (ie *IfExpression) String() string {
+
+	var out bytes.Buffer
+
+	out.WriteString("if")
+	out.WriteString(ie.Condition.String())
+	out.WriteString(" ")
+	out.WriteString(ie.Consequence.String())
+
+	if ie.Alternative != nil {
+		out.WriteString("else ")
+		out.WriteString(ie.Alternative.String())
+	}
+
+	return out.String()
+}
+
+/**
+ * 名前: IfExpression.Pos
+ * 概要: if式の開始位置('if'トークン)を返す
+ */
+func (ie *IfExpression) Pos() token.Position {
+	return ie.Token.Pos()
+}
+
+/**
+ * 名前: IfExpression.EndPos
+ * 概要: if式の終了位置を返す
+ *	Alternativeが存在する場合はAlternativeの終了位置、存在しない場合はConsequenceの終了位置を返す
+ */
+func (ie *IfExpression) EndPos() token.Position {
+
+	if ie.Alternative != nil {
+		return ie.Alternative.EndPos()
+	}
+
+	return ie.Consequence.EndPos()
+}
+
+// 関数リテラル (例: fn(x, y) { ... }) を表すノード
+type FunctionLiteral struct {
+	Token      token.Token   // 'fn' トークン
+	Parameters []*Identifier // 仮引数の配列
+	Body       *BlockStatement
+}
+
+/**
+ * 名前: FunctionLiteral.expressionNode
+ * 概要:
+ *	関数リテラルをExpressionとして扱うためのマーカーメソッド
+ *  Expressionインターフェースを満たす
+ */
+func (fl *FunctionLiteral) expressionNode() {}
+
+/**
+ * 名前: FunctionLiteral.TokenLiteral
+ * 概要:
+ *	関数リテラルのトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (fl *FunctionLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+/**
+ * 名前: FunctionLiteral.String
+ * 概要:
+ *	関数リテラルを "fn(仮引数1, 仮引数2, ...) 本体" の形式の文字列にして返す
+ *  Nodeインターフェースを満たす
+ */
+func (fl *FunctionLiteral) String() string {
+
+	var out bytes.Buffer
+
+	params := []string{}
+	for _, p := range fl.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString(fl.TokenLiteral())
+	out.WriteString("(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") ")
+	out.WriteString(fl.Body.String())
+
+	return out.String()
+}
+
+/**
+ * 名前: FunctionLiteral.Pos
+ * 概要: 関数リテラルの開始位置('fn'トークン)を返す
+ */
+func (fl *FunctionLiteral) Pos() token.Position {
+	return fl.Token.Pos()
+}
+
+/**
+ * 名前: FunctionLiteral.EndPos
+ * 概要: 関数リテラルの終了位置を返す (本体の終了位置)
+ */
+func (fl *FunctionLiteral) EndPos() token.Position {
+	return fl.Body.EndPos()
+}
+
+// 関数呼び出し式 (例: add(1, 2)) を表すノード
+type CallExpression struct {
+	Token     token.Token  // '(' トークン
+	Function  Expression   // 呼び出される識別子 または 関数リテラル
+	Arguments []Expression // 呼び出しの引数
+}
+
+/**
+ * 名前: CallExpression.expressionNode
+ * 概要:
+ *	関数呼び出し式をExpressionとして扱うためのマーカーメソッド
+ *  Expressionインターフェースを満たす
+ */
+func (ce *CallExpression) expressionNode() {}
+
+/**
+ * 名前: CallExpression.TokenLiteral
+ * 概要:
+ *	関数呼び出し式のトークンリテラルを返す
+ *  TokenLiteralインターフェースを満たす
+ */
+func (ce *CallExpression) TokenLiteral() string {
+	return ce.Token.Literal
+}
+
+/**
+ * 名前: CallExpression.String
+ * 概要:
+ *	関数呼び出し式を "関数(引数1, 引数2, ...)" の形式の文字列にして返す
+ *  Nodeインターフェースを満たす
+ */
+func (ce *CallExpression) String() string {
+
+	var out bytes.Buffer
+
+	args := []string{}
+	for _, a := range ce.Arguments {
+		args = append(args, a.String())
+	}
+
+	out.WriteString(ce.Function.String())
+	out.WriteString("(")
+	out.WriteString(strings.Join(args, ", "))
+	out.WriteString(")")
+
+	return out.String()
+}
+
+/**
+ * 名前: CallExpression.Pos
+ * 概要: 関数呼び出し式の開始位置を返す (呼び出される式の開始位置)
+ */
+func (ce *CallExpression) Pos() token.Position {
+	return ce.Function.Pos()
+}
+
+/**
+ * 名前: CallExpression.EndPos
+ * 概要: 関数呼び出し式の終了位置を返す
+ *	末尾の引数の終了位置を返す。引数が1つも無い場合は呼び出される式の終了位置を返す
+ */
+func (ce *CallExpression) EndPos() token.Position {
+
+	if len(ce.Arguments) > 0 {
+		return ce.Arguments[len(ce.Arguments)-1].EndPos()
+	}
+
+	return ce.Function.EndPos()
+}
@@ -0,0 +1,238 @@
+/**
+ * パッケージ名: ast
+ * ファイル名: ast_test.go
+ * 概要: 抽象構文木のテストを実装する
+ */
+package ast
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MasaruFukazawa/monkey-lang/src/token"
+)
+
+func TestLetStatementPos(t *testing.T) {
+
+	letTok := token.Token{Type: token.LET, Literal: "let", Filename: "main.monkey", Line: 1, Column: 1, Offset: 0}
+	nameTok := token.Token{Type: token.IDENT, Literal: "x", Filename: "main.monkey", Line: 1, Column: 5, Offset: 4}
+	valueTok := token.Token{Type: token.INT, Literal: "5", Filename: "main.monkey", Line: 1, Column: 9, Offset: 8}
+
+	stmt := &LetStatement{
+		Token: letTok,
+		Name:  &Identifier{Token: nameTok, Value: "x"},
+		Value: &IntegerLiteral{Token: valueTok, Value: 5},
+	}
+
+	if pos := stmt.Pos(); pos.Line != 1 || pos.Column != 1 {
+		t.Fatalf("stmt.Pos() wrong. expected={1 1}, got={%d %d}", pos.Line, pos.Column)
+	}
+
+	if end := stmt.EndPos(); end.Line != 1 || end.Column != 9 {
+		t.Fatalf("stmt.EndPos() wrong. expected={1 9}, got={%d %d}", end.Line, end.Column)
+	}
+}
+
+func TestLetStatementEndPosWithoutValue(t *testing.T) {
+
+	letTok := token.Token{Type: token.LET, Literal: "let", Line: 1, Column: 1}
+	nameTok := token.Token{Type: token.IDENT, Literal: "x", Line: 1, Column: 5}
+
+	stmt := &LetStatement{
+		Token: letTok,
+		Name:  &Identifier{Token: nameTok, Value: "x"},
+	}
+
+	if end := stmt.EndPos(); end.Column != 5 {
+		t.Fatalf("stmt.EndPos() wrong. expected column=5, got=%d", end.Column)
+	}
+}
+
+func TestReturnStatementPos(t *testing.T) {
+
+	returnTok := token.Token{Type: token.RETURN, Literal: "return", Line: 2, Column: 1}
+	valueTok := token.Token{Type: token.INT, Literal: "5", Line: 2, Column: 8}
+
+	stmt := &ReturnStatement{
+		Token:       returnTok,
+		ReturnValue: &IntegerLiteral{Token: valueTok, Value: 5},
+	}
+
+	if pos := stmt.Pos(); pos.Column != 1 {
+		t.Fatalf("stmt.Pos() wrong. expected column=1, got=%d", pos.Column)
+	}
+
+	if end := stmt.EndPos(); end.Column != 8 {
+		t.Fatalf("stmt.EndPos() wrong. expected column=8, got=%d", end.Column)
+	}
+}
+
+func TestExpressionStatementPos(t *testing.T) {
+
+	identTok := token.Token{Type: token.IDENT, Literal: "x", Line: 1, Column: 1}
+
+	stmt := &ExpressionStatement{
+		Token:      identTok,
+		Expression: &Identifier{Token: identTok, Value: "x"},
+	}
+
+	if pos, end := stmt.Pos(), stmt.EndPos(); pos.Column != 1 || end.Column != 1 {
+		t.Fatalf("stmt.Pos()/EndPos() wrong. expected both column=1, got=%d/%d", pos.Column, end.Column)
+	}
+}
+
+func TestProgramPos(t *testing.T) {
+
+	first := &ExpressionStatement{
+		Token:      token.Token{Type: token.IDENT, Literal: "x", Line: 1, Column: 1},
+		Expression: &Identifier{Token: token.Token{Type: token.IDENT, Literal: "x", Line: 1, Column: 1}, Value: "x"},
+	}
+	second := &ExpressionStatement{
+		Token:      token.Token{Type: token.IDENT, Literal: "y", Line: 2, Column: 1},
+		Expression: &Identifier{Token: token.Token{Type: token.IDENT, Literal: "y", Line: 2, Column: 1}, Value: "y"},
+	}
+
+	program := &Program{Statements: []Statement{first, second}}
+
+	if pos := program.Pos(); pos.Line != 1 {
+		t.Fatalf("program.Pos() wrong. expected line=1, got=%d", pos.Line)
+	}
+
+	if end := program.EndPos(); end.Line != 2 {
+		t.Fatalf("program.EndPos() wrong. expected line=2, got=%d", end.Line)
+	}
+}
+
+func TestStringLiteralString(t *testing.T) {
+
+	sl := &StringLiteral{Token: token.Token{Type: token.STRING, Literal: "foobar"}, Value: "foobar"}
+
+	expected := `"foobar"`
+	if sl.String() != expected {
+		t.Fatalf("sl.String() wrong. expected=%q, got=%q", expected, sl.String())
+	}
+}
+
+func TestArrayLiteralString(t *testing.T) {
+
+	array := &ArrayLiteral{
+		Elements: []Expression{
+			&IntegerLiteral{Token: token.Token{Literal: "1"}, Value: 1},
+			&IntegerLiteral{Token: token.Token{Literal: "2"}, Value: 2},
+			&IntegerLiteral{Token: token.Token{Literal: "3"}, Value: 3},
+		},
+	}
+
+	expected := "[1, 2, 3]"
+	if array.String() != expected {
+		t.Fatalf("array.String() wrong. expected=%q, got=%q", expected, array.String())
+	}
+}
+
+func TestIndexExpressionString(t *testing.T) {
+
+	ie := &IndexExpression{
+		Left:  &Identifier{Value: "arr"},
+		Index: &IntegerLiteral{Token: token.Token{Literal: "0"}, Value: 0},
+	}
+
+	expected := "(arr[0])"
+	if ie.String() != expected {
+		t.Fatalf("ie.String() wrong. expected=%q, got=%q", expected, ie.String())
+	}
+}
+
+func TestHashLiteralStringSinglePair(t *testing.T) {
+
+	hash := &HashLiteral{
+		Pairs: map[Expression]Expression{
+			&StringLiteral{Token: token.Token{Literal: "foo"}, Value: "foo"}: &StringLiteral{Token: token.Token{Literal: "bar"}, Value: "bar"},
+		},
+	}
+
+	expected := `{"foo":"bar"}`
+	if hash.String() != expected {
+		t.Fatalf("hash.String() wrong. expected=%q, got=%q", expected, hash.String())
+	}
+}
+
+func TestInspectVisitsEveryNode(t *testing.T) {
+
+	// let x = add(1, 2); に相当する木を手組みし、Inspectで巡回されるノードの
+	// 種類を数え上げる
+	call := &CallExpression{
+		Function: &Identifier{Value: "add"},
+		Arguments: []Expression{
+			&IntegerLiteral{Token: token.Token{Literal: "1"}, Value: 1},
+			&IntegerLiteral{Token: token.Token{Literal: "2"}, Value: 2},
+		},
+	}
+	letStmt := &LetStatement{
+		Name:  &Identifier{Value: "x"},
+		Value: call,
+	}
+	program := &Program{Statements: []Statement{letStmt}}
+
+	var visited []string
+	Inspect(program, func(n Node) bool {
+		visited = append(visited, fmt.Sprintf("%T", n))
+		return true
+	})
+
+	expected := []string{
+		"*ast.Program",
+		"*ast.LetStatement",
+		"*ast.Identifier",
+		"*ast.CallExpression",
+		"*ast.Identifier",
+		"*ast.IntegerLiteral",
+		"*ast.IntegerLiteral",
+	}
+
+	if len(visited) != len(expected) {
+		t.Fatalf("visited wrong length. expected=%d, got=%d (%v)", len(expected), len(visited), visited)
+	}
+
+	for i, typeName := range expected {
+		if visited[i] != typeName {
+			t.Fatalf("visited[%d] wrong. expected=%q, got=%q", i, typeName, visited[i])
+		}
+	}
+}
+
+func TestInspectStopsDescentWhenFalse(t *testing.T) {
+
+	program := &Program{
+		Statements: []Statement{
+			&LetStatement{
+				Name:  &Identifier{Value: "x"},
+				Value: &IntegerLiteral{Token: token.Token{Literal: "5"}, Value: 5},
+			},
+		},
+	}
+
+	var visited int
+	Inspect(program, func(n Node) bool {
+		visited++
+		// LetStatement自体は数えるが、子ノードには潜らない
+		_, isLet := n.(*LetStatement)
+		return !isLet
+	})
+
+	if visited != 2 {
+		t.Fatalf("visited count wrong. expected=2 (Program, LetStatement), got=%d", visited)
+	}
+}
+
+func TestEmptyProgramPos(t *testing.T) {
+
+	program := &Program{}
+
+	if pos := program.Pos(); pos != (token.Position{}) {
+		t.Fatalf("empty program.Pos() wrong. expected zero value, got=%+v", pos)
+	}
+
+	if end := program.EndPos(); end != (token.Position{}) {
+		t.Fatalf("empty program.EndPos() wrong. expected zero value, got=%+v", end)
+	}
+}
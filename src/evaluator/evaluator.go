@@ -0,0 +1,526 @@
+/**
+ * パッケージ名: evaluator
+ * ファイル名: evaluator.go
+ * 概要: 抽象構文木を評価し、値(object.Object)を求める
+ * いわゆる tree-walking interpreter の中心となるパッケージ
+ */
+package evaluator
+
+import (
+	"fmt"
+
+	"github.com/MasaruFukazawa/monkey-lang/src/ast"
+	"github.com/MasaruFukazawa/monkey-lang/src/object"
+)
+
+// 使い回すために事前に生成しておくシングルトン
+// Boolean/Nullは不変なので、評価の度に生成し直す必要が無い
+var (
+	NULL  = &object.Null{}
+	TRUE  = &object.Boolean{Value: true}
+	FALSE = &object.Boolean{Value: false}
+)
+
+/**
+ * 名前: Eval
+ * 処理: ノードを評価し、対応するオブジェクトを返す
+ *       ノードの種類に応じて処理を振り分ける
+ * 引数: node: 評価対象のノード, env: 現在の環境(変数束縛)
+ * 戻り値: object.Object
+ */
+func Eval(node ast.Node, env *object.Environment) object.Object {
+
+	switch node := node.(type) {
+
+	// 文
+	case *ast.Program:
+		return evalProgram(node, env)
+
+	case *ast.ExpressionStatement:
+		return Eval(node.Expression, env)
+
+	case *ast.BlockStatement:
+		return evalBlockStatement(node, env)
+
+	case *ast.ReturnStatement:
+		val := Eval(node.ReturnValue, env)
+		if isError(val) {
+			return val
+		}
+		return &object.ReturnValue{Value: val}
+
+	case *ast.LetStatement:
+		val := Eval(node.Value, env)
+		if isError(val) {
+			return val
+		}
+		env.Set(node.Name.Value, val)
+		return val
+
+	// 式
+	case *ast.IntegerLiteral:
+		return &object.Integer{Value: node.Value}
+
+	case *ast.StringLiteral:
+		return &object.String{Value: node.Value}
+
+	case *ast.Boolean:
+		return nativeBoolToBooleanObject(node.Value)
+
+	case *ast.PrefixExpression:
+		right := Eval(node.Right, env)
+		if isError(right) {
+			return right
+		}
+		return evalPrefixExpression(node.Operator, right)
+
+	case *ast.InfixExpression:
+		left := Eval(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		right := Eval(node.Right, env)
+		if isError(right) {
+			return right
+		}
+		return evalInfixExpression(node.Operator, left, right)
+
+	case *ast.IfExpression:
+		return evalIfExpression(node, env)
+
+	case *ast.Identifier:
+		return evalIdentifier(node, env)
+
+	case *ast.FunctionLiteral:
+		return &object.Function{Parameters: node.Parameters, Body: node.Body, Env: env}
+
+	case *ast.CallExpression:
+		function := Eval(node.Function, env)
+		if isError(function) {
+			return function
+		}
+		args := evalExpressions(node.Arguments, env)
+		if len(args) == 1 && isError(args[0]) {
+			return args[0]
+		}
+		return applyFunction(function, args)
+
+	case *ast.ArrayLiteral:
+		elements := evalExpressions(node.Elements, env)
+		if len(elements) == 1 && isError(elements[0]) {
+			return elements[0]
+		}
+		return &object.Array{Elements: elements}
+
+	case *ast.IndexExpression:
+		left := Eval(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		index := Eval(node.Index, env)
+		if isError(index) {
+			return index
+		}
+		return evalIndexExpression(left, index)
+
+	case *ast.HashLiteral:
+		return evalHashLiteral(node, env)
+	}
+
+	return newError("unknown node type: %T", node)
+}
+
+/**
+ * 名前: evalProgram
+ * 処理: プログラム全体の文を順に評価する
+ *       ReturnValue/Errorが現れた時点で評価を打ち切り、中身を返す
+ */
+func evalProgram(program *ast.Program, env *object.Environment) object.Object {
+
+	var result object.Object
+
+	for _, statement := range program.Statements {
+
+		result = Eval(statement, env)
+
+		switch result := result.(type) {
+		case *object.ReturnValue:
+			return result.Value
+		case *object.Error:
+			return result
+		}
+	}
+
+	return result
+}
+
+/**
+ * 名前: evalBlockStatement
+ * 処理: ブロック内の文を順に評価する
+ *       ReturnValue/Errorが現れた時点で評価を打ち切り、外側に伝播させる
+ *       (evalProgramと異なり、ReturnValueの中身を展開しない)
+ */
+func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
+
+	var result object.Object
+
+	for _, statement := range block.Statements {
+
+		result = Eval(statement, env)
+
+		if result != nil {
+			rt := result.Type()
+			if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ {
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+/**
+ * 名前: nativeBoolToBooleanObject
+ * 処理: Goのboolを、使い回し用のobject.Booleanシングルトンに変換する
+ */
+func nativeBoolToBooleanObject(input bool) *object.Boolean {
+	if input {
+		return TRUE
+	}
+	return FALSE
+}
+
+/**
+ * 名前: evalPrefixExpression
+ * 処理: 前置式を評価する
+ */
+func evalPrefixExpression(operator string, right object.Object) object.Object {
+	switch operator {
+	case "!":
+		return evalBangOperatorExpression(right)
+	case "-":
+		return evalMinusPrefixOperatorExpression(right)
+	default:
+		return newError("unknown operator: %s%s", operator, right.Type())
+	}
+}
+
+/**
+ * 名前: evalBangOperatorExpression
+ * 処理: "!" 演算子を評価する。false と null のみ真とみなして反転する
+ */
+func evalBangOperatorExpression(right object.Object) object.Object {
+	switch right {
+	case TRUE:
+		return FALSE
+	case FALSE:
+		return TRUE
+	case NULL:
+		return TRUE
+	default:
+		return FALSE
+	}
+}
+
+/**
+ * 名前: evalMinusPrefixOperatorExpression
+ * 処理: 単項の "-" 演算子を評価する。整数以外はエラーとする
+ */
+func evalMinusPrefixOperatorExpression(right object.Object) object.Object {
+
+	if right.Type() != object.INTEGER_OBJ {
+		return newError("unknown operator: -%s", right.Type())
+	}
+
+	value := right.(*object.Integer).Value
+
+	return &object.Integer{Value: -value}
+}
+
+/**
+ * 名前: evalInfixExpression
+ * 処理: 中置式を評価する。左右のオペランドの型に応じて処理を振り分ける
+ */
+func evalInfixExpression(operator string, left, right object.Object) object.Object {
+	switch {
+	case left.Type() == object.INTEGER_OBJ && right.Type() == object.INTEGER_OBJ:
+		return evalIntegerInfixExpression(operator, left, right)
+	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
+		return evalStringInfixExpression(operator, left, right)
+	case operator == "==":
+		return nativeBoolToBooleanObject(left == right)
+	case operator == "!=":
+		return nativeBoolToBooleanObject(left != right)
+	case left.Type() != right.Type():
+		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+/**
+ * 名前: evalIntegerInfixExpression
+ * 処理: 整数同士の中置式を評価する
+ */
+func evalIntegerInfixExpression(operator string, left, right object.Object) object.Object {
+
+	leftVal := left.(*object.Integer).Value
+	rightVal := right.(*object.Integer).Value
+
+	switch operator {
+	case "+":
+		return &object.Integer{Value: leftVal + rightVal}
+	case "-":
+		return &object.Integer{Value: leftVal - rightVal}
+	case "*":
+		return &object.Integer{Value: leftVal * rightVal}
+	case "/":
+		if rightVal == 0 {
+			return newError("division by zero: %d / %d", leftVal, rightVal)
+		}
+		return &object.Integer{Value: leftVal / rightVal}
+	case "<":
+		return nativeBoolToBooleanObject(leftVal < rightVal)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal > rightVal)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal == rightVal)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal != rightVal)
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
+/**
+ * 名前: evalStringInfixExpression
+ * 処理: 文字列同士の中置式を評価する。現時点では連結(+)のみ対応する
+ */
+func evalStringInfixExpression(operator string, left, right object.Object) object.Object {
+
+	if operator != "+" {
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+
+	leftVal := left.(*object.String).Value
+	rightVal := right.(*object.String).Value
+
+	return &object.String{Value: leftVal + rightVal}
+}
+
+/**
+ * 名前: evalIfExpression
+ * 処理: if式を評価する。条件が真ならConsequence、偽ならAlternative(あれば)を評価する
+ */
+func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Object {
+
+	condition := Eval(ie.Condition, env)
+	if isError(condition) {
+		return condition
+	}
+
+	if isTruthy(condition) {
+		return Eval(ie.Consequence, env)
+	} else if ie.Alternative != nil {
+		return Eval(ie.Alternative, env)
+	}
+
+	return NULL
+}
+
+/**
+ * 名前: isTruthy
+ * 処理: オブジェクトの真偽値を判定する。false と null のみ偽、それ以外は真
+ */
+func isTruthy(obj object.Object) bool {
+	switch obj {
+	case NULL:
+		return false
+	case TRUE:
+		return true
+	case FALSE:
+		return false
+	default:
+		return true
+	}
+}
+
+/**
+ * 名前: evalIdentifier
+ * 処理: 識別子を現在の環境から検索する。見つからない場合はエラーとする
+ */
+func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
+
+	if val, ok := env.Get(node.Value); ok {
+		return val
+	}
+
+	return newError("identifier not found: " + node.Value)
+}
+
+/**
+ * 名前: evalExpressions
+ * 処理: 式の並び(関数呼び出しの引数、配列の要素など)を順に評価する
+ *       途中でエラーが発生した場合は、そのエラーのみを含むスライスを返す
+ */
+func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Object {
+
+	var result []object.Object
+
+	for _, e := range exps {
+		evaluated := Eval(e, env)
+		if isError(evaluated) {
+			return []object.Object{evaluated}
+		}
+		result = append(result, evaluated)
+	}
+
+	return result
+}
+
+/**
+ * 名前: applyFunction
+ * 処理: 関数を呼び出す。引数を束縛した新しい環境で本体を評価する
+ */
+func applyFunction(fn object.Object, args []object.Object) object.Object {
+
+	function, ok := fn.(*object.Function)
+	if !ok {
+		return newError("not a function: %s", fn.Type())
+	}
+
+	extendedEnv := extendFunctionEnv(function, args)
+	evaluated := Eval(function.Body, extendedEnv)
+
+	return unwrapReturnValue(evaluated)
+}
+
+/**
+ * 名前: extendFunctionEnv
+ * 処理: 関数の捕捉した環境を親として、仮引数に実引数を束縛した環境を生成する
+ */
+func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Environment {
+
+	env := object.NewEnclosedEnvironment(fn.Env)
+
+	for paramIdx, param := range fn.Parameters {
+		env.Set(param.Value, args[paramIdx])
+	}
+
+	return env
+}
+
+/**
+ * 名前: unwrapReturnValue
+ * 処理: 関数本体の評価結果がReturnValueの場合、中身を取り出す
+ *       (取り出さないと、入れ子の関数呼び出しの外側までreturnが伝播してしまう)
+ */
+func unwrapReturnValue(obj object.Object) object.Object {
+	if returnValue, ok := obj.(*object.ReturnValue); ok {
+		return returnValue.Value
+	}
+	return obj
+}
+
+/**
+ * 名前: evalIndexExpression
+ * 処理: 添字式を評価する。左辺の型に応じて処理を振り分ける
+ */
+func evalIndexExpression(left, index object.Object) object.Object {
+	switch {
+	case left.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalArrayIndexExpression(left, index)
+	case left.Type() == object.HASH_OBJ:
+		return evalHashIndexExpression(left, index)
+	default:
+		return newError("index operator not supported: %s", left.Type())
+	}
+}
+
+/**
+ * 名前: evalArrayIndexExpression
+ * 処理: 配列の添字式を評価する。範囲外の場合はNULLを返す
+ */
+func evalArrayIndexExpression(array, index object.Object) object.Object {
+
+	arrayObject := array.(*object.Array)
+	idx := index.(*object.Integer).Value
+	max := int64(len(arrayObject.Elements) - 1)
+
+	if idx < 0 || idx > max {
+		return NULL
+	}
+
+	return arrayObject.Elements[idx]
+}
+
+/**
+ * 名前: evalHashLiteral
+ * 処理: ハッシュリテラルを評価する。キーがHashableでない場合はエラーとする
+ */
+func evalHashLiteral(node *ast.HashLiteral, env *object.Environment) object.Object {
+
+	pairs := make(map[object.HashKey]object.HashPair)
+
+	for keyNode, valueNode := range node.Pairs {
+
+		key := Eval(keyNode, env)
+		if isError(key) {
+			return key
+		}
+
+		hashKey, ok := key.(object.Hashable)
+		if !ok {
+			return newError("unusable as hash key: %s", key.Type())
+		}
+
+		value := Eval(valueNode, env)
+		if isError(value) {
+			return value
+		}
+
+		pairs[hashKey.HashKey()] = object.HashPair{Key: key, Value: value}
+	}
+
+	return &object.Hash{Pairs: pairs}
+}
+
+/**
+ * 名前: evalHashIndexExpression
+ * 処理: ハッシュの添字式を評価する。キーが存在しない場合はNULLを返す
+ */
+func evalHashIndexExpression(hash, index object.Object) object.Object {
+
+	hashObject := hash.(*object.Hash)
+
+	key, ok := index.(object.Hashable)
+	if !ok {
+		return newError("unusable as hash key: %s", index.Type())
+	}
+
+	pair, ok := hashObject.Pairs[key.HashKey()]
+	if !ok {
+		return NULL
+	}
+
+	return pair.Value
+}
+
+/**
+ * 名前: newError
+ * 処理: object.Errorを生成する。fmt.Sprintfと同じ書式を使える
+ */
+func newError(format string, a ...interface{}) *object.Error {
+	return &object.Error{Message: fmt.Sprintf(format, a...)}
+}
+
+/**
+ * 名前: isError
+ * 処理: オブジェクトがobject.Errorかどうかを判定する。nilの場合はfalseを返す
+ */
+func isError(obj object.Object) bool {
+	if obj != nil {
+		return obj.Type() == object.ERROR_OBJ
+	}
+	return false
+}
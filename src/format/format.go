@@ -0,0 +1,309 @@
+/**
+ * パッケージ名: format
+ * ファイル名: format.go
+ * 概要: Monkeyソースコードの整形(プリティプリント)を行う
+ * ast.Nodeの String() はデバッグ用の単一行表現であるため、
+ * このパッケージでは String() を使わずに改めてノードを描画する。
+ * go/printer が Go言語に対して担う役割を Monkey 言語に対して担う。
+ */
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/MasaruFukazawa/monkey-lang/src/ast"
+	"github.com/MasaruFukazawa/monkey-lang/src/lexer"
+	"github.com/MasaruFukazawa/monkey-lang/src/parser"
+)
+
+// インデント1段分の文字列
+const indentUnit = "    "
+
+// 1行にまとめると読みにくいとみなし、引数を折り返す呼び出し式の文字数のしきい値
+const callWrapWidth = 40
+
+/**
+ * 名前: Node
+ * 処理: ノードを正規化されたMonkeyソースコードとしてwに書き出す
+ * 引数: w: 書き出し先, node: 整形対象のノード (通常は *ast.Program)
+ * 戻り値: error
+ */
+func Node(w io.Writer, node ast.Node) error {
+
+	var out bytes.Buffer
+
+	switch n := node.(type) {
+	case *ast.Program:
+		writeStatements(&out, n.Statements, 0)
+	case ast.Statement:
+		writeStatement(&out, n, 0)
+	case ast.Expression:
+		writeExpr(&out, n, 0)
+		out.WriteString(";\n")
+	default:
+		return fmt.Errorf("format: unsupported node type %T", node)
+	}
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+/**
+ * 名前: Source
+ * 処理: Monkeyソースコードをレキシング・パースし、正規化したソースコードを返す
+ * 引数: src: 整形対象のソースコード
+ * 戻り値: []byte, error
+ */
+func Source(src []byte) ([]byte, error) {
+
+	l := lexer.New(string(src))
+	p := parser.New(l)
+
+	program := p.ParseProgram()
+
+	if errs := p.Errors(); len(errs) != 0 {
+		return nil, fmt.Errorf("format: %s", strings.Join(errs, "; "))
+	}
+
+	var out bytes.Buffer
+	if err := Node(&out, program); err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+/**
+ * 名前: indent
+ * 処理: depth段分のインデントをoutに書き出す
+ */
+func indent(out *bytes.Buffer, depth int) {
+	out.WriteString(strings.Repeat(indentUnit, depth))
+}
+
+/**
+ * 名前: writeStatements
+ * 処理: 文の並びをdepth段インデントして描画する
+ *       連続する let 文は 1 つのグループとみなし、"=" の位置を揃える
+ */
+func writeStatements(out *bytes.Buffer, stmts []ast.Statement, depth int) {
+
+	for i := 0; i < len(stmts); {
+
+		if _, ok := stmts[i].(*ast.LetStatement); ok {
+			j := i
+			width := 0
+			for j < len(stmts) {
+				next, ok := stmts[j].(*ast.LetStatement)
+				if !ok {
+					break
+				}
+				if len(next.Name.Value) > width {
+					width = len(next.Name.Value)
+				}
+				j++
+			}
+
+			for k := i; k < j; k++ {
+				writeLet(out, stmts[k].(*ast.LetStatement), width, depth)
+			}
+
+			i = j
+			continue
+		}
+
+		writeStatement(out, stmts[i], depth)
+		i++
+	}
+}
+
+/**
+ * 名前: writeStatement
+ * 処理: 1つの文をdepth段インデントして描画する (let文のグループ整列は行わない単体描画)
+ */
+func writeStatement(out *bytes.Buffer, stmt ast.Statement, depth int) {
+	switch s := stmt.(type) {
+	case *ast.LetStatement:
+		writeLet(out, s, len(s.Name.Value), depth)
+	case *ast.ReturnStatement:
+		writeReturn(out, s, depth)
+	case *ast.ExpressionStatement:
+		if s.Expression != nil {
+			indent(out, depth)
+			writeExpr(out, s.Expression, depth)
+			out.WriteString(";\n")
+		}
+	case *ast.BlockStatement:
+		indent(out, depth)
+		writeBlock(out, s, depth)
+		out.WriteString("\n")
+	}
+}
+
+/**
+ * 名前: writeLet
+ * 処理: let文を "let <name> = <value>;" の形式で描画する
+ *       nameWidthを指定すると、"=" の前の桁をそろえてグループ整列する
+ */
+func writeLet(out *bytes.Buffer, ls *ast.LetStatement, nameWidth int, depth int) {
+
+	indent(out, depth)
+	out.WriteString("let ")
+	out.WriteString(ls.Name.Value)
+	out.WriteString(strings.Repeat(" ", nameWidth-len(ls.Name.Value)))
+	out.WriteString(" = ")
+
+	if ls.Value != nil {
+		writeExpr(out, ls.Value, depth)
+	}
+
+	out.WriteString(";\n")
+}
+
+/**
+ * 名前: writeReturn
+ * 処理: return文を "return <value>;" の形式で描画する
+ */
+func writeReturn(out *bytes.Buffer, rs *ast.ReturnStatement, depth int) {
+
+	indent(out, depth)
+	out.WriteString("return")
+
+	if rs.ReturnValue != nil {
+		out.WriteString(" ")
+		writeExpr(out, rs.ReturnValue, depth)
+	}
+
+	out.WriteString(";\n")
+}
+
+/**
+ * 名前: writeBlock
+ * 処理: ブロック文を "{\n  ...\n}" の形式で描画する。中身はdepth+1段インデントする
+ *       閉じ"}"自体はdepth段に揃える (呼び出し側で開始インデントは書き出し済みの前提)
+ */
+func writeBlock(out *bytes.Buffer, block *ast.BlockStatement, depth int) {
+	out.WriteString("{\n")
+	writeStatements(out, block.Statements, depth+1)
+	indent(out, depth)
+	out.WriteString("}")
+}
+
+/**
+ * 名前: writeExpr
+ * 処理: 式を描画する。ast.Node.String() は使わず、ノードの種類ごとに描画する
+ */
+func writeExpr(out *bytes.Buffer, expr ast.Expression, depth int) {
+
+	switch e := expr.(type) {
+	case *ast.Identifier:
+		out.WriteString(e.Value)
+	case *ast.IntegerLiteral:
+		out.WriteString(e.Token.Literal)
+	case *ast.Boolean:
+		out.WriteString(e.Token.Literal)
+	case *ast.StringLiteral:
+		fmt.Fprintf(out, "%q", e.Value)
+	case *ast.ArrayLiteral:
+		out.WriteString("[")
+		for i, el := range e.Elements {
+			if i > 0 {
+				out.WriteString(", ")
+			}
+			writeExpr(out, el, depth)
+		}
+		out.WriteString("]")
+	case *ast.IndexExpression:
+		writeExpr(out, e.Left, depth)
+		out.WriteString("[")
+		writeExpr(out, e.Index, depth)
+		out.WriteString("]")
+	case *ast.HashLiteral:
+		out.WriteString("{")
+		i := 0
+		for key, value := range e.Pairs {
+			if i > 0 {
+				out.WriteString(", ")
+			}
+			writeExpr(out, key, depth)
+			out.WriteString(": ")
+			writeExpr(out, value, depth)
+			i++
+		}
+		out.WriteString("}")
+	case *ast.PrefixExpression:
+		out.WriteString(e.Operator)
+		writeExpr(out, e.Right, depth)
+	case *ast.InfixExpression:
+		out.WriteString("(")
+		writeExpr(out, e.Left, depth)
+		out.WriteString(" ")
+		out.WriteString(e.Operator)
+		out.WriteString(" ")
+		writeExpr(out, e.Right, depth)
+		out.WriteString(")")
+	case *ast.IfExpression:
+		out.WriteString("if (")
+		writeExpr(out, e.Condition, depth)
+		out.WriteString(") ")
+		writeBlock(out, e.Consequence, depth)
+		if e.Alternative != nil {
+			out.WriteString(" else ")
+			writeBlock(out, e.Alternative, depth)
+		}
+	case *ast.FunctionLiteral:
+		out.WriteString("fn(")
+		for i, param := range e.Parameters {
+			if i > 0 {
+				out.WriteString(", ")
+			}
+			out.WriteString(param.Value)
+		}
+		out.WriteString(") ")
+		writeBlock(out, e.Body, depth)
+	case *ast.CallExpression:
+		writeCall(out, e, depth)
+	default:
+		// 未対応のノード種別はデバッグ表現にフォールバックする
+		out.WriteString(expr.String())
+	}
+}
+
+/**
+ * 名前: writeCall
+ * 処理: 関数呼び出し式を描画する。1行に収めた結果がcallWrapWidthに収まるなら
+ *       そのまま1行で、収まらなければ引数を1つずつ改行・インデントして描画する
+ */
+func writeCall(out *bytes.Buffer, ce *ast.CallExpression, depth int) {
+
+	var oneLine bytes.Buffer
+	writeExpr(&oneLine, ce.Function, depth)
+	oneLine.WriteString("(")
+	for i, a := range ce.Arguments {
+		if i > 0 {
+			oneLine.WriteString(", ")
+		}
+		writeExpr(&oneLine, a, depth)
+	}
+	oneLine.WriteString(")")
+
+	if len(ce.Arguments) == 0 || oneLine.Len() <= callWrapWidth {
+		out.Write(oneLine.Bytes())
+		return
+	}
+
+	writeExpr(out, ce.Function, depth)
+	out.WriteString("(\n")
+
+	for _, a := range ce.Arguments {
+		indent(out, depth+1)
+		writeExpr(out, a, depth+1)
+		out.WriteString(",\n")
+	}
+
+	indent(out, depth)
+	out.WriteString(")")
+}
@@ -0,0 +1,91 @@
+/**
+ * パッケージ名: format
+ * ファイル名: format_test.go
+ * 概要: Monkeyソースコードの整形のテストを実装する
+ */
+package format
+
+import "testing"
+
+func TestSourceAlignsLetGroup(t *testing.T) {
+
+	input := "let x = 5;\nlet foo = 10;\n"
+	expected := "let x   = 5;\nlet foo = 10;\n"
+
+	out, err := Source([]byte(input))
+	if err != nil {
+		t.Fatalf("Source returned an error: %v", err)
+	}
+
+	if string(out) != expected {
+		t.Fatalf("Source() wrong.\nexpected=%q\ngot=%q", expected, string(out))
+	}
+}
+
+func TestSourceInfixSpacing(t *testing.T) {
+
+	input := "1 + 2 * 3;"
+	expected := "(1 + (2 * 3));\n"
+
+	out, err := Source([]byte(input))
+	if err != nil {
+		t.Fatalf("Source returned an error: %v", err)
+	}
+
+	if string(out) != expected {
+		t.Fatalf("Source() wrong.\nexpected=%q\ngot=%q", expected, string(out))
+	}
+}
+
+func TestSourceIndentsIfBlocks(t *testing.T) {
+
+	input := "if (x) { return 1; } else { return 2; }"
+	expected := "if (x) {\n    return 1;\n} else {\n    return 2;\n};\n"
+
+	out, err := Source([]byte(input))
+	if err != nil {
+		t.Fatalf("Source returned an error: %v", err)
+	}
+
+	if string(out) != expected {
+		t.Fatalf("Source() wrong.\nexpected=%q\ngot=%q", expected, string(out))
+	}
+}
+
+func TestSourceWrapsLongCallExpression(t *testing.T) {
+
+	input := "add(1111111, 2222222, 3333333, 4444444, 5555555);"
+	expected := "add(\n    1111111,\n    2222222,\n    3333333,\n    4444444,\n    5555555,\n);\n"
+
+	out, err := Source([]byte(input))
+	if err != nil {
+		t.Fatalf("Source returned an error: %v", err)
+	}
+
+	if string(out) != expected {
+		t.Fatalf("Source() wrong.\nexpected=%q\ngot=%q", expected, string(out))
+	}
+}
+
+func TestSourceKeepsShortCallOnOneLine(t *testing.T) {
+
+	input := "add(1, 2);"
+	expected := "add(1, 2);\n"
+
+	out, err := Source([]byte(input))
+	if err != nil {
+		t.Fatalf("Source returned an error: %v", err)
+	}
+
+	if string(out) != expected {
+		t.Fatalf("Source() wrong.\nexpected=%q\ngot=%q", expected, string(out))
+	}
+}
+
+func TestSourceReportsParseErrors(t *testing.T) {
+
+	_, err := Source([]byte("let = 5;"))
+	if err == nil {
+		t.Fatalf("expected Source to return an error for invalid input, got nil")
+	}
+}
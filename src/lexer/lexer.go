@@ -0,0 +1,305 @@
+/**
+ * パッケージ名: lexer
+ * ファイル名: lexer.go
+ * 概要: 字句解析器を実装する
+ * 字句解析器とは、ソースコードをトークンに分割する処理を行うもの。
+ */
+package lexer
+
+import (
+	"io"
+
+	"github.com/MasaruFukazawa/monkey-lang/src/token"
+)
+
+// 字句解析器を表す構造体
+type Lexer struct {
+	input        string // 解析対象の入力文字列
+	filename     string // 解析対象のファイル名 (REPL入力等では空文字列)
+	position     int    // 現在の文字を指す位置
+	readPosition int    // 次に読み込む文字を指す位置
+	ch           byte   // 現在検査中の文字
+
+	line   int // 現在検査中の文字の行番号 (1始まり)
+	column int // 現在検査中の文字の桁番号 (1始まり)
+}
+
+/**
+ * 名前: New
+ * 処理: 字句解析器を生成する
+ * 引数: input: 解析対象の入力文字列
+ * 戻り値: *Lexer
+ */
+func New(input string) *Lexer {
+
+	l := &Lexer{input: input, line: 1, column: 0}
+	l.readChar()
+
+	return l
+}
+
+/**
+ * 名前: NewWithFilename
+ * 処理: ファイル名を保持した字句解析器を生成する
+ * 引数: r: 解析対象を読み込む io.Reader, filename: 解析対象のファイル名
+ * 戻り値: *Lexer, error
+ */
+func NewWithFilename(r io.Reader, filename string) (*Lexer, error) {
+
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Lexer{input: string(b), filename: filename, line: 1, column: 0}
+	l.readChar()
+
+	return l, nil
+}
+
+/**
+ * 名前: readChar
+ * 処理: 次の文字を読み込み、現在検査中の文字を更新する
+ *       あわせて行番号・桁番号を更新する
+ */
+func (l *Lexer) readChar() {
+
+	if l.readPosition >= len(l.input) {
+		l.ch = 0
+	} else {
+		l.ch = l.input[l.readPosition]
+	}
+
+	l.position = l.readPosition
+	l.readPosition++
+
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	} else {
+		l.column++
+	}
+}
+
+/**
+ * 名前: peekChar
+ * 処理: 次の文字を先読みする (position は進めない)
+ * 戻り値: byte
+ */
+func (l *Lexer) peekChar() byte {
+
+	if l.readPosition >= len(l.input) {
+		return 0
+	}
+
+	return l.input[l.readPosition]
+}
+
+/**
+ * 名前: NextToken
+ * 処理: 次のトークンを読み込む
+ * 戻り値: token.Token
+ */
+func (l *Lexer) NextToken() token.Token {
+
+	var tok token.Token
+
+	l.skipWhitespace()
+
+	line, column, offset := l.line, l.column, l.position
+
+	switch l.ch {
+	case '=':
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			literal := string(ch) + string(l.ch)
+			tok = token.Token{Type: token.EQ, Literal: literal}
+		} else {
+			tok = newToken(token.ASSIGN, l.ch)
+		}
+	case '+':
+		tok = newToken(token.PLUS, l.ch)
+	case '-':
+		tok = newToken(token.MINUS, l.ch)
+	case '!':
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			literal := string(ch) + string(l.ch)
+			tok = token.Token{Type: token.NOT_EQ, Literal: literal}
+		} else {
+			tok = newToken(token.BANG, l.ch)
+		}
+	case '/':
+		tok = newToken(token.SLASH, l.ch)
+	case '*':
+		tok = newToken(token.ASTERISK, l.ch)
+	case '<':
+		tok = newToken(token.LT, l.ch)
+	case '>':
+		tok = newToken(token.GT, l.ch)
+	case ';':
+		tok = newToken(token.SEMICOLON, l.ch)
+	case ',':
+		tok = newToken(token.COMMA, l.ch)
+	case '(':
+		tok = newToken(token.LPAREN, l.ch)
+	case ')':
+		tok = newToken(token.RPAREN, l.ch)
+	case '{':
+		tok = newToken(token.LBRACE, l.ch)
+	case '}':
+		tok = newToken(token.RBRACE, l.ch)
+	case '[':
+		tok = newToken(token.LBRACKET, l.ch)
+	case ']':
+		tok = newToken(token.RBRACKET, l.ch)
+	case ':':
+		tok = newToken(token.COLON, l.ch)
+	case '"':
+		tok.Type = token.STRING
+		tok.Literal = l.readString()
+		tok.Filename, tok.Line, tok.Column, tok.Offset = l.filename, line, column, offset
+		l.readChar()
+		return tok
+	case 0:
+		tok.Literal = ""
+		tok.Type = token.EOF
+	default:
+		if isLetter(l.ch) {
+			tok.Literal = l.readIdentifier()
+			tok.Type = token.LookupIdent(tok.Literal)
+			tok.Filename, tok.Line, tok.Column, tok.Offset = l.filename, line, column, offset
+			return tok
+		} else if isDigit(l.ch) {
+			tok.Literal = l.readNumber()
+			tok.Type = token.INT
+			tok.Filename, tok.Line, tok.Column, tok.Offset = l.filename, line, column, offset
+			return tok
+		} else {
+			tok = newToken(token.ILLEGAL, l.ch)
+		}
+	}
+
+	tok.Filename, tok.Line, tok.Column, tok.Offset = l.filename, line, column, offset
+
+	l.readChar()
+
+	return tok
+}
+
+/**
+ * 名前: newToken
+ * 処理: 1文字分のトークンを生成する (位置情報は呼び出し側で設定する)
+ * 引数: tokenType: トークンの種類, ch: トークンの文字
+ * 戻り値: token.Token
+ */
+func newToken(tokenType token.TokenType, ch byte) token.Token {
+	return token.Token{Type: tokenType, Literal: string(ch)}
+}
+
+/**
+ * 名前: readIdentifier
+ * 処理: 識別子(変数名・関数名)を読み込む
+ * 戻り値: string
+ */
+func (l *Lexer) readIdentifier() string {
+
+	position := l.position
+
+	for isLetter(l.ch) {
+		l.readChar()
+	}
+
+	return l.input[position:l.position]
+}
+
+/**
+ * 名前: readNumber
+ * 処理: 数値リテラルを読み込む
+ * 戻り値: string
+ */
+func (l *Lexer) readNumber() string {
+
+	position := l.position
+
+	for isDigit(l.ch) {
+		l.readChar()
+	}
+
+	return l.input[position:l.position]
+}
+
+/**
+ * 名前: readString
+ * 処理: 文字列リテラルを読み込む
+ *       開始・終了の`"`は含まない。エスケープシーケンス(\", \\, \n, \t)を解釈する
+ * 戻り値: string
+ */
+func (l *Lexer) readString() string {
+
+	var out []byte
+
+	for {
+		l.readChar()
+
+		if l.ch == '"' || l.ch == 0 {
+			break
+		}
+
+		if l.ch == '\\' {
+			switch l.peekChar() {
+			case '"':
+				out = append(out, '"')
+				l.readChar()
+			case '\\':
+				out = append(out, '\\')
+				l.readChar()
+			case 'n':
+				out = append(out, '\n')
+				l.readChar()
+			case 't':
+				out = append(out, '\t')
+				l.readChar()
+			default:
+				out = append(out, l.ch)
+			}
+			continue
+		}
+
+		out = append(out, l.ch)
+	}
+
+	return string(out)
+}
+
+/**
+ * 名前: skipWhitespace
+ * 処理: 空白文字を読み飛ばす
+ */
+func (l *Lexer) skipWhitespace() {
+	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+		l.readChar()
+	}
+}
+
+/**
+ * 名前: isLetter
+ * 処理: 引数の文字がアルファベットまたはアンダースコアかどうかを判定する
+ * 引数: ch: 判定対象の文字
+ * 戻り値: bool
+ */
+func isLetter(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+}
+
+/**
+ * 名前: isDigit
+ * 処理: 引数の文字が数字かどうかを判定する
+ * 引数: ch: 判定対象の文字
+ * 戻り値: bool
+ */
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
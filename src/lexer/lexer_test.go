@@ -6,6 +6,7 @@
 package lexer
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/MasaruFukazawa/monkey-lang/src/token"
@@ -188,3 +189,99 @@ if (5 < 10) {
 
 	}
 }
+
+func TestNextTokenPositions(t *testing.T) {
+
+	// 2行目の"y"が行番号2・桁番号5・オフセット6であることを確認する
+	input := "let x = 1;\nlet y = 2;"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+		expectedLine    int
+		expectedColumn  int
+		expectedOffset  int
+	}{
+		{token.LET, "let", 1, 1, 0},
+		{token.IDENT, "x", 1, 5, 4},
+		{token.ASSIGN, "=", 1, 7, 6},
+		{token.INT, "1", 1, 9, 8},
+		{token.SEMICOLON, ";", 1, 10, 9},
+		{token.LET, "let", 2, 1, 11},
+		{token.IDENT, "y", 2, 5, 15},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - token type wrong. expected=%q, got=%q", i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+
+		if tok.Line != tt.expectedLine {
+			t.Fatalf("tests[%d] - line wrong. expected=%d, got=%d", i, tt.expectedLine, tok.Line)
+		}
+
+		if tok.Column != tt.expectedColumn {
+			t.Fatalf("tests[%d] - column wrong. expected=%d, got=%d", i, tt.expectedColumn, tok.Column)
+		}
+
+		if tok.Offset != tt.expectedOffset {
+			t.Fatalf("tests[%d] - offset wrong. expected=%d, got=%d", i, tt.expectedOffset, tok.Offset)
+		}
+	}
+}
+
+func TestNewWithFilename(t *testing.T) {
+
+	l, err := NewWithFilename(strings.NewReader("let x = 5;"), "main.monkey")
+	if err != nil {
+		t.Fatalf("NewWithFilename returned an error: %v", err)
+	}
+
+	tok := l.NextToken()
+
+	if tok.Filename != "main.monkey" {
+		t.Fatalf("tok.Filename wrong. expected=%q, got=%q", "main.monkey", tok.Filename)
+	}
+
+	if tok.Type != token.LET || tok.Literal != "let" {
+		t.Fatalf("tok wrong. expected={%q %q}, got={%q %q}", token.LET, "let", tok.Type, tok.Literal)
+	}
+}
+
+func TestStringLiteralEscapes(t *testing.T) {
+
+	input := `"hello \"world\"" "a\\b" "line1\nline2" "a\tb"`
+
+	tests := []struct {
+		expectedLiteral string
+	}{
+		{`hello "world"`},
+		{`a\b`},
+		{"line1\nline2"},
+		{"a\tb"},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+
+		tok := l.NextToken()
+
+		if tok.Type != token.STRING {
+			t.Fatalf("tests[%d] - token type wrong. expected=%q, got=%q", i, token.STRING, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q", i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
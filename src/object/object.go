@@ -0,0 +1,259 @@
+/**
+ * パッケージ名: object
+ * ファイル名: object.go
+ * 概要: 評価器が扱う値(オブジェクト)の定義
+ * Monkeyの全ての値は Object インターフェースを満たすオブジェクトとして表現される。
+ */
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/MasaruFukazawa/monkey-lang/src/ast"
+)
+
+type ObjectType string
+
+const (
+	INTEGER_OBJ      = "INTEGER"
+	BOOLEAN_OBJ      = "BOOLEAN"
+	NULL_OBJ         = "NULL"
+	RETURN_VALUE_OBJ = "RETURN_VALUE"
+	ERROR_OBJ        = "ERROR"
+	FUNCTION_OBJ     = "FUNCTION"
+	STRING_OBJ       = "STRING"
+	ARRAY_OBJ        = "ARRAY"
+	HASH_OBJ         = "HASH"
+)
+
+// 全てのオブジェクトが満たすインターフェース
+type Object interface {
+	Type() ObjectType // オブジェクトの種類を返す
+	Inspect() string  // オブジェクトの値を表示用の文字列にして返す
+}
+
+// 整数を表すオブジェクト
+type Integer struct {
+	Value int64
+}
+
+func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
+func (i *Integer) Inspect() string  { return fmt.Sprintf("%d", i.Value) }
+
+// 真偽値を表すオブジェクト
+type Boolean struct {
+	Value bool
+}
+
+func (b *Boolean) Type() ObjectType { return BOOLEAN_OBJ }
+func (b *Boolean) Inspect() string  { return fmt.Sprintf("%t", b.Value) }
+
+// 値が存在しないことを表すオブジェクト
+type Null struct{}
+
+func (n *Null) Type() ObjectType { return NULL_OBJ }
+func (n *Null) Inspect() string  { return "null" }
+
+// 文字列を表すオブジェクト
+type String struct {
+	Value string
+}
+
+func (s *String) Type() ObjectType { return STRING_OBJ }
+func (s *String) Inspect() string  { return s.Value }
+
+// return文の戻り値を包むオブジェクト
+// ブロック文の評価を途中で打ち切り、呼び出し元まで値を伝播させるために使う
+type ReturnValue struct {
+	Value Object
+}
+
+func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
+func (rv *ReturnValue) Inspect() string  { return rv.Value.Inspect() }
+
+// 評価中に発生したエラーを表すオブジェクト
+// ReturnValueと同様、発生した時点で評価を打ち切り、呼び出し元まで伝播させる
+type Error struct {
+	Message string
+}
+
+func (e *Error) Type() ObjectType { return ERROR_OBJ }
+func (e *Error) Inspect() string  { return "ERROR: " + e.Message }
+
+// 関数を表すオブジェクト
+// 定義時の環境(Env)を保持することでクロージャを実現する
+type Function struct {
+	Parameters []*ast.Identifier
+	Body       *ast.BlockStatement
+	Env        *Environment
+}
+
+func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
+
+func (f *Function) Inspect() string {
+
+	var out bytes.Buffer
+
+	params := []string{}
+	for _, p := range f.Parameters {
+		params = append(params, p.String())
+	}
+
+	out.WriteString("fn")
+	out.WriteString("(")
+	out.WriteString(strings.Join(params, ", "))
+	out.WriteString(") {\n")
+	out.WriteString(f.Body.String())
+	out.WriteString("\n}")
+
+	return out.String()
+}
+
+// 配列を表すオブジェクト
+type Array struct {
+	Elements []Object
+}
+
+func (ao *Array) Type() ObjectType { return ARRAY_OBJ }
+
+func (ao *Array) Inspect() string {
+
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, e := range ao.Elements {
+		elements = append(elements, e.Inspect())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+// ハッシュのキーとして使う値を一意に表す構造体
+type HashKey struct {
+	Type  ObjectType
+	Value uint64
+}
+
+// ハッシュのキーとして使えるオブジェクトが満たすインターフェース
+type Hashable interface {
+	HashKey() HashKey
+}
+
+func (b *Boolean) HashKey() HashKey {
+
+	var value uint64
+
+	if b.Value {
+		value = 1
+	} else {
+		value = 0
+	}
+
+	return HashKey{Type: b.Type(), Value: value}
+}
+
+func (i *Integer) HashKey() HashKey {
+	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
+}
+
+func (s *String) HashKey() HashKey {
+
+	h := fnv.New64a()
+	h.Write([]byte(s.Value))
+
+	return HashKey{Type: s.Type(), Value: h.Sum64()}
+}
+
+// ハッシュの1要素 (元のキーオブジェクトと値の組)
+type HashPair struct {
+	Key   Object
+	Value Object
+}
+
+// ハッシュを表すオブジェクト
+type Hash struct {
+	Pairs map[HashKey]HashPair
+}
+
+func (h *Hash) Type() ObjectType { return HASH_OBJ }
+
+func (h *Hash) Inspect() string {
+
+	var out bytes.Buffer
+
+	pairs := []string{}
+	for _, pair := range h.Pairs {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", pair.Key.Inspect(), pair.Value.Inspect()))
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// 変数名と値の束縛を保持する環境
+// 関数呼び出しやブロックごとにネストした環境を作ることで、スコープを表現する
+type Environment struct {
+	store map[string]Object
+	outer *Environment // 外側(親)の環境。トップレベルではnil
+}
+
+/**
+ * 名前: NewEnvironment
+ * 処理: 外側の環境を持たない、トップレベルの環境を生成する
+ * 戻り値: *Environment
+ */
+func NewEnvironment() *Environment {
+	return &Environment{store: make(map[string]Object)}
+}
+
+/**
+ * 名前: NewEnclosedEnvironment
+ * 処理: outerを親として持つ、入れ子になった環境を生成する
+ *       関数呼び出し時の引数束縛やクロージャの実現に使う
+ * 引数: outer: 親となる環境
+ * 戻り値: *Environment
+ */
+func NewEnclosedEnvironment(outer *Environment) *Environment {
+	env := NewEnvironment()
+	env.outer = outer
+	return env
+}
+
+/**
+ * 名前: Environment.Get
+ * 処理: 名前に束縛された値を取得する
+ *       自身の環境に存在しない場合は、親の環境を遡って探索する
+ * 引数: name: 変数名
+ * 戻り値: Object, bool (見つかったかどうか)
+ */
+func (e *Environment) Get(name string) (Object, bool) {
+
+	obj, ok := e.store[name]
+
+	if !ok && e.outer != nil {
+		obj, ok = e.outer.Get(name)
+	}
+
+	return obj, ok
+}
+
+/**
+ * 名前: Environment.Set
+ * 処理: 自身の環境に名前と値を束縛する
+ * 引数: name: 変数名, val: 束縛する値
+ * 戻り値: Object (valそのもの)
+ */
+func (e *Environment) Set(name string, val Object) Object {
+	e.store[name] = val
+	return val
+}
@@ -0,0 +1,95 @@
+/**
+ * パッケージ名: object
+ * ファイル名: object_test.go
+ * 概要: オブジェクトと環境のテストを実装する
+ */
+package object
+
+import "testing"
+
+func TestStringHashKey(t *testing.T) {
+
+	hello1 := &String{Value: "Hello World"}
+	hello2 := &String{Value: "Hello World"}
+	diff1 := &String{Value: "My name is johnny"}
+	diff2 := &String{Value: "My name is johnny"}
+
+	if hello1.HashKey() != hello2.HashKey() {
+		t.Errorf("strings with same content have different hash keys")
+	}
+
+	if diff1.HashKey() != diff2.HashKey() {
+		t.Errorf("strings with same content have different hash keys")
+	}
+
+	if hello1.HashKey() == diff1.HashKey() {
+		t.Errorf("strings with different content have same hash keys")
+	}
+}
+
+func TestIntegerAndBooleanHashKey(t *testing.T) {
+
+	tests := []struct {
+		a, b  Object
+		equal bool
+	}{
+		{&Integer{Value: 1}, &Integer{Value: 1}, true},
+		{&Integer{Value: 1}, &Integer{Value: 2}, false},
+		{&Boolean{Value: true}, &Boolean{Value: true}, true},
+		{&Boolean{Value: true}, &Boolean{Value: false}, false},
+	}
+
+	for i, tt := range tests {
+
+		ha := tt.a.(Hashable).HashKey()
+		hb := tt.b.(Hashable).HashKey()
+
+		if (ha == hb) != tt.equal {
+			t.Errorf("tests[%d] - hash key equality wrong. expected=%t, got=%t", i, tt.equal, ha == hb)
+		}
+	}
+}
+
+func TestEnvironmentGetSet(t *testing.T) {
+
+	env := NewEnvironment()
+
+	if _, ok := env.Get("x"); ok {
+		t.Fatalf("env.Get(\"x\") should not find a value in an empty environment")
+	}
+
+	env.Set("x", &Integer{Value: 5})
+
+	val, ok := env.Get("x")
+	if !ok {
+		t.Fatalf("env.Get(\"x\") did not find the value just set")
+	}
+
+	if val.(*Integer).Value != 5 {
+		t.Fatalf("env.Get(\"x\") wrong. expected=5, got=%d", val.(*Integer).Value)
+	}
+}
+
+func TestEnclosedEnvironmentFallsBackToOuter(t *testing.T) {
+
+	outer := NewEnvironment()
+	outer.Set("x", &Integer{Value: 5})
+
+	inner := NewEnclosedEnvironment(outer)
+
+	val, ok := inner.Get("x")
+	if !ok {
+		t.Fatalf("inner.Get(\"x\") did not fall back to the outer environment")
+	}
+
+	if val.(*Integer).Value != 5 {
+		t.Fatalf("inner.Get(\"x\") wrong. expected=5, got=%d", val.(*Integer).Value)
+	}
+
+	inner.Set("x", &Integer{Value: 10})
+
+	outerVal, _ := outer.Get("x")
+	if outerVal.(*Integer).Value != 5 {
+		t.Fatalf("inner.Set(\"x\") leaked into the outer environment. outer x=%d", outerVal.(*Integer).Value)
+	}
+}
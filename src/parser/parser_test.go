@@ -0,0 +1,502 @@
+/**
+ * パッケージ名: parser
+ * ファイル名: parser_test.go
+ * 概要: 構文解析器のテストを実装する
+ */
+package parser
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MasaruFukazawa/monkey-lang/src/ast"
+	"github.com/MasaruFukazawa/monkey-lang/src/lexer"
+)
+
+func checkParserErrors(t *testing.T, p *Parser) {
+
+	errors := p.Errors()
+	if len(errors) == 0 {
+		return
+	}
+
+	t.Errorf("parser has %d errors", len(errors))
+	for _, msg := range errors {
+		t.Errorf("parser error: %s", msg)
+	}
+	t.FailNow()
+}
+
+func TestLetStatements(t *testing.T) {
+
+	tests := []struct {
+		input              string
+		expectedIdentifier string
+		expectedValue      interface{}
+	}{
+		{"let x = 5;", "x", int64(5)},
+		{"let y = true;", "y", true},
+		{"let foobar = y;", "foobar", "y"},
+	}
+
+	for i, tt := range tests {
+
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("tests[%d] - program.Statements does not contain 1 statement. got=%d", i, len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.LetStatement)
+		if !ok {
+			t.Fatalf("tests[%d] - stmt is not *ast.LetStatement. got=%T", i, program.Statements[0])
+		}
+
+		if stmt.Name.Value != tt.expectedIdentifier {
+			t.Fatalf("tests[%d] - stmt.Name.Value wrong. expected=%q, got=%q", i, tt.expectedIdentifier, stmt.Name.Value)
+		}
+
+		testLiteralExpression(t, stmt.Value, tt.expectedValue)
+	}
+}
+
+func TestReturnStatements(t *testing.T) {
+
+	tests := []struct {
+		input         string
+		expectedValue interface{}
+	}{
+		{"return 5;", int64(5)},
+		{"return true;", true},
+		{"return foobar;", "foobar"},
+	}
+
+	for i, tt := range tests {
+
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("tests[%d] - program.Statements does not contain 1 statement. got=%d", i, len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ReturnStatement)
+		if !ok {
+			t.Fatalf("tests[%d] - stmt is not *ast.ReturnStatement. got=%T", i, program.Statements[0])
+		}
+
+		testLiteralExpression(t, stmt.ReturnValue, tt.expectedValue)
+	}
+}
+
+func TestOperatorPrecedenceParsing(t *testing.T) {
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"-a * b", "((-a) * b)"},
+		{"!-a", "(!(-a))"},
+		{"a + b + c", "((a + b) + c)"},
+		{"a + b - c", "((a + b) - c)"},
+		{"a * b * c", "((a * b) * c)"},
+		{"a * b / c", "((a * b) / c)"},
+		{"a + b / c", "(a + (b / c))"},
+		{"a + b * c + d / e - f", "(((a + (b * c)) + (d / e)) - f)"},
+		{"3 + 4; -5 * 5", "(3 + 4)((-5) * 5)"},
+		{"5 > 4 == 3 < 4", "((5 > 4) == (3 < 4))"},
+		{"5 < 4 != 3 > 4", "((5 < 4) != (3 > 4))"},
+		{"3 + 4 * 5 == 3 * 1 + 4 * 5", "((3 + (4 * 5)) == ((3 * 1) + (4 * 5)))"},
+		{"1 + (2 + 3) + 4", "((1 + (2 + 3)) + 4)"},
+		{"(5 + 5) * 2", "((5 + 5) * 2)"},
+		{"2 / (5 + 5)", "(2 / (5 + 5))"},
+		{"-(5 + 5)", "(-(5 + 5))"},
+		{"!(true == true)", "(!(true == true))"},
+		{"a + add(b * c) + d", "((a + add((b * c))) + d)"},
+		{"add(a, b, 1, 2 * 3, 4 + 5, add(6, 7 * 8))", "add(a, b, 1, (2 * 3), (4 + 5), add(6, (7 * 8)))"},
+		{"add(a + b + c * d / f + g)", "add((((a + b) + ((c * d) / f)) + g))"},
+		{"a * [1, 2, 3, 4][b * c] * d", "((a * ([1, 2, 3, 4][(b * c)])) * d)"},
+		{"add(a * b[2], b[1], 2 * [1, 2][1])", "add((a * (b[2])), (b[1]), (2 * ([1, 2][1])))"},
+	}
+
+	for i, tt := range tests {
+
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		actual := program.String()
+		if actual != tt.expected {
+			t.Errorf("tests[%d] - wrong precedence. expected=%q, got=%q", i, tt.expected, actual)
+		}
+	}
+}
+
+func TestIfExpression(t *testing.T) {
+
+	input := `if (x < y) { x }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("stmt is not *ast.ExpressionStatement. got=%T", program.Statements[0])
+	}
+
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not *ast.IfExpression. got=%T", stmt.Expression)
+	}
+
+	if len(exp.Consequence.Statements) != 1 {
+		t.Fatalf("consequence does not contain 1 statement. got=%d", len(exp.Consequence.Statements))
+	}
+
+	if exp.Alternative != nil {
+		t.Fatalf("exp.Alternative was not nil. got=%+v", exp.Alternative)
+	}
+}
+
+func TestIfElseExpression(t *testing.T) {
+
+	input := `if (x < y) { x } else { y }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.IfExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not *ast.IfExpression. got=%T", stmt.Expression)
+	}
+
+	if len(exp.Consequence.Statements) != 1 {
+		t.Fatalf("consequence does not contain 1 statement. got=%d", len(exp.Consequence.Statements))
+	}
+
+	if exp.Alternative == nil {
+		t.Fatalf("exp.Alternative was nil")
+	}
+
+	if len(exp.Alternative.Statements) != 1 {
+		t.Fatalf("alternative does not contain 1 statement. got=%d", len(exp.Alternative.Statements))
+	}
+}
+
+func TestFunctionLiteralParsing(t *testing.T) {
+
+	input := `fn(x, y) { x + y; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	function, ok := stmt.Expression.(*ast.FunctionLiteral)
+	if !ok {
+		t.Fatalf("stmt.Expression is not *ast.FunctionLiteral. got=%T", stmt.Expression)
+	}
+
+	if len(function.Parameters) != 2 {
+		t.Fatalf("function literal parameters wrong. expected 2, got=%d", len(function.Parameters))
+	}
+
+	testLiteralExpression(t, function.Parameters[0], "x")
+	testLiteralExpression(t, function.Parameters[1], "y")
+
+	if len(function.Body.Statements) != 1 {
+		t.Fatalf("function.Body.Statements does not contain 1 statement. got=%d", len(function.Body.Statements))
+	}
+
+	bodyStmt, ok := function.Body.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("function body stmt is not *ast.ExpressionStatement. got=%T", function.Body.Statements[0])
+	}
+
+	testInfixExpression(t, bodyStmt.Expression, "x", "+", "y")
+}
+
+func TestFunctionParameterParsing(t *testing.T) {
+
+	tests := []struct {
+		input          string
+		expectedParams []string
+	}{
+		{"fn() {};", []string{}},
+		{"fn(x) {};", []string{"x"}},
+		{"fn(x, y, z) {};", []string{"x", "y", "z"}},
+	}
+
+	for i, tt := range tests {
+
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		function := stmt.Expression.(*ast.FunctionLiteral)
+
+		if len(function.Parameters) != len(tt.expectedParams) {
+			t.Fatalf("tests[%d] - length parameters wrong. expected=%d, got=%d", i, len(tt.expectedParams), len(function.Parameters))
+		}
+
+		for j, ident := range tt.expectedParams {
+			testLiteralExpression(t, function.Parameters[j], ident)
+		}
+	}
+}
+
+func TestCallExpressionParsing(t *testing.T) {
+
+	input := "add(1, 2 * 3, 4 + 5);"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	exp, ok := stmt.Expression.(*ast.CallExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not *ast.CallExpression. got=%T", stmt.Expression)
+	}
+
+	testIdentifier(t, exp.Function, "add")
+
+	if len(exp.Arguments) != 3 {
+		t.Fatalf("wrong length of arguments. got=%d", len(exp.Arguments))
+	}
+
+	testLiteralExpression(t, exp.Arguments[0], int64(1))
+	testInfixExpression(t, exp.Arguments[1], int64(2), "*", int64(3))
+	testInfixExpression(t, exp.Arguments[2], int64(4), "+", int64(5))
+}
+
+func TestIndexExpressionParsing(t *testing.T) {
+
+	input := "myArray[1 + 1]"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	indexExp, ok := stmt.Expression.(*ast.IndexExpression)
+	if !ok {
+		t.Fatalf("stmt.Expression is not *ast.IndexExpression. got=%T", stmt.Expression)
+	}
+
+	testIdentifier(t, indexExp.Left, "myArray")
+	testInfixExpression(t, indexExp.Index, int64(1), "+", int64(1))
+}
+
+func TestHashLiteralParsing(t *testing.T) {
+
+	input := `{"one": 1, "two": 2, "three": 3}`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	hash, ok := stmt.Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("stmt.Expression is not *ast.HashLiteral. got=%T", stmt.Expression)
+	}
+
+	if len(hash.Pairs) != 3 {
+		t.Fatalf("hash.Pairs has wrong length. got=%d", len(hash.Pairs))
+	}
+
+	expected := map[string]int64{"one": 1, "two": 2, "three": 3}
+
+	for key, value := range hash.Pairs {
+
+		literal, ok := key.(*ast.StringLiteral)
+		if !ok {
+			t.Fatalf("key is not *ast.StringLiteral. got=%T", key)
+		}
+
+		expectedValue := expected[literal.Value]
+		testIntegerLiteral(t, value, expectedValue)
+	}
+}
+
+func TestHashLiteralParsingEmpty(t *testing.T) {
+
+	input := "{}"
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	hash, ok := stmt.Expression.(*ast.HashLiteral)
+	if !ok {
+		t.Fatalf("stmt.Expression is not *ast.HashLiteral. got=%T", stmt.Expression)
+	}
+
+	if len(hash.Pairs) != 0 {
+		t.Fatalf("hash.Pairs has wrong length. got=%d", len(hash.Pairs))
+	}
+}
+
+func TestMalformedLetStatement(t *testing.T) {
+
+	tests := []struct {
+		input       string
+		expectedErr string
+	}{
+		{"let x 5;", "expected next token to be =, got INT instead"},
+		{"let = 5;", "expected next token to be IDENT, got = instead"},
+		{"let 838383;", "expected next token to be IDENT, got INT instead"},
+	}
+
+	for i, tt := range tests {
+
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Fatalf("tests[%d] - expected a parser error, got none", i)
+		}
+
+		if errors[0] != tt.expectedErr {
+			t.Fatalf("tests[%d] - wrong error. expected=%q, got=%q", i, tt.expectedErr, errors[0])
+		}
+	}
+}
+
+func TestNoPrefixParseFnError(t *testing.T) {
+
+	input := ")"
+
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 error, got=%d (%v)", len(errors), errors)
+	}
+
+	expected := "no prefix parse function for ) found"
+	if errors[0] != expected {
+		t.Fatalf("wrong error. expected=%q, got=%q", expected, errors[0])
+	}
+}
+
+func TestUnterminatedGroupedExpressionError(t *testing.T) {
+
+	input := "(1 + 2"
+
+	l := lexer.New(input)
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("expected 1 error, got=%d (%v)", len(errors), errors)
+	}
+
+	expected := "expected next token to be ), got EOF instead"
+	if errors[0] != expected {
+		t.Fatalf("wrong error. expected=%q, got=%q", expected, errors[0])
+	}
+}
+
+func testLiteralExpression(t *testing.T, exp ast.Expression, expected interface{}) {
+
+	switch v := expected.(type) {
+	case int64:
+		testIntegerLiteral(t, exp, v)
+	case string:
+		testIdentifier(t, exp, v)
+	case bool:
+		testBooleanLiteral(t, exp, v)
+	default:
+		t.Fatalf("type of exp not handled. got=%T", exp)
+	}
+}
+
+func testIntegerLiteral(t *testing.T, il ast.Expression, value int64) {
+
+	integ, ok := il.(*ast.IntegerLiteral)
+	if !ok {
+		t.Fatalf("il is not *ast.IntegerLiteral. got=%T", il)
+	}
+
+	if integ.Value != value {
+		t.Fatalf("integ.Value wrong. expected=%d, got=%d", value, integ.Value)
+	}
+
+	if integ.TokenLiteral() != fmt.Sprintf("%d", value) {
+		t.Fatalf("integ.TokenLiteral wrong. expected=%d, got=%s", value, integ.TokenLiteral())
+	}
+}
+
+func testIdentifier(t *testing.T, exp ast.Expression, value string) {
+
+	ident, ok := exp.(*ast.Identifier)
+	if !ok {
+		t.Fatalf("exp is not *ast.Identifier. got=%T", exp)
+	}
+
+	if ident.Value != value {
+		t.Fatalf("ident.Value wrong. expected=%s, got=%s", value, ident.Value)
+	}
+
+	if ident.TokenLiteral() != value {
+		t.Fatalf("ident.TokenLiteral wrong. expected=%s, got=%s", value, ident.TokenLiteral())
+	}
+}
+
+func testBooleanLiteral(t *testing.T, exp ast.Expression, value bool) {
+
+	b, ok := exp.(*ast.Boolean)
+	if !ok {
+		t.Fatalf("exp is not *ast.Boolean. got=%T", exp)
+	}
+
+	if b.Value != value {
+		t.Fatalf("b.Value wrong. expected=%t, got=%t", value, b.Value)
+	}
+}
+
+func testInfixExpression(t *testing.T, exp ast.Expression, left interface{}, operator string, right interface{}) {
+
+	opExp, ok := exp.(*ast.InfixExpression)
+	if !ok {
+		t.Fatalf("exp is not *ast.InfixExpression. got=%T(%s)", exp, exp)
+	}
+
+	testLiteralExpression(t, opExp.Left, left)
+
+	if opExp.Operator != operator {
+		t.Fatalf("opExp.Operator wrong. expected=%q, got=%q", operator, opExp.Operator)
+	}
+
+	testLiteralExpression(t, opExp.Right, right)
+}
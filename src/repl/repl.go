@@ -0,0 +1,70 @@
+/**
+ * パッケージ名: repl
+ * ファイル名: repl.go
+ * 概要: 対話的にMonkeyのコードを実行するためのREPL(Read-Eval-Print Loop)
+ * 1行読み込むごとに 字句解析 -> 構文解析 -> 評価 を行い、結果の値を表示する。
+ * 環境(object.Environment)は1セッションの間保持するので、
+ * 前の行で定義した変数や関数を後の行から参照できる。
+ */
+package repl
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/MasaruFukazawa/monkey-lang/src/evaluator"
+	"github.com/MasaruFukazawa/monkey-lang/src/lexer"
+	"github.com/MasaruFukazawa/monkey-lang/src/object"
+	"github.com/MasaruFukazawa/monkey-lang/src/parser"
+)
+
+const PROMPT = ">> "
+
+/**
+ * 名前: Start
+ * 処理: REPLを開始する。inから1行読み込むたびに構文解析・評価を行い、
+ *       得られた値をoutに表示する。構文解析エラーがあれば、評価は行わず
+ *       エラー内容を表示する
+ * 引数: in: 入力元, out: 出力先
+ */
+func Start(in io.Reader, out io.Writer) {
+
+	scanner := bufio.NewScanner(in)
+	env := object.NewEnvironment()
+
+	for {
+		fmt.Fprint(out, PROMPT)
+
+		if !scanner.Scan() {
+			return
+		}
+
+		line := scanner.Text()
+		l := lexer.New(line)
+		p := parser.New(l)
+
+		program := p.ParseProgram()
+
+		if len(p.Errors()) != 0 {
+			printParserErrors(out, p.Errors())
+			continue
+		}
+
+		evaluated := evaluator.Eval(program, env)
+		if evaluated != nil {
+			io.WriteString(out, evaluated.Inspect())
+			io.WriteString(out, "\n")
+		}
+	}
+}
+
+/**
+ * 名前: printParserErrors
+ * 処理: 構文解析中に発生したエラーの一覧をoutに表示する
+ */
+func printParserErrors(out io.Writer, errors []string) {
+	for _, msg := range errors {
+		io.WriteString(out, "\t"+msg+"\n")
+	}
+}
@@ -15,7 +15,8 @@ const (
 	IDENT = "IDENT"
 
 	// リテラル : 扱うデータの型
-	INT = "INT"
+	INT    = "INT"
+	STRING = "STRING"
 
 	// 演算子 : 使用できる演算子
 	ASSIGN   = "="
@@ -34,6 +35,7 @@ const (
 	// デリミタ(区切り文字) : コード上の区切り文字
 	COMMA     = ","
 	SEMICOLON = ";"
+	COLON     = ":"
 
 	LPAREN = "("
 	RPAREN = ")"
@@ -41,6 +43,9 @@ const (
 	LBRACE = "{"
 	RBRACE = "}"
 
+	LBRACKET = "["
+	RBRACKET = "]"
+
 	// キーワード : コード上で使用する予約語
 	FUNCTION = "FUNCTION" // 関数定義
 	LET      = "LET"      // 変数定義
@@ -57,6 +62,35 @@ type TokenType string
 type Token struct {
 	Type    TokenType // トークンタイプ
 	Literal string    // トークン文字列
+
+	// ソース上の位置情報
+	Filename string // トークンが属するファイル名 (REPL入力等では空文字列)
+	Line     int    // 1始まりの行番号
+	Column   int    // 1始まりの桁番号
+	Offset   int    // 入力全体の先頭からのバイトオフセット
+}
+
+// トークンが位置するソース上の範囲を表す構造体
+// ast.Node.Pos() / ast.Node.EndPos() の戻り値として利用する
+type Position struct {
+	Filename string
+	Line     int
+	Column   int
+	Offset   int
+}
+
+/**
+ * 名前: Token.Pos
+ * 処理: トークン自身の位置情報を Position に変換する
+ * 戻り値: Position
+ */
+func (t Token) Pos() Position {
+	return Position{
+		Filename: t.Filename,
+		Line:     t.Line,
+		Column:   t.Column,
+		Offset:   t.Offset,
+	}
 }
 
 // 予約語のマップ
@@ -0,0 +1,38 @@
+/**
+ * パッケージ名: token
+ * ファイル名: token_test.go
+ * 概要: トークンのテストを実装する
+ */
+package token
+
+import "testing"
+
+func TestTokenPos(t *testing.T) {
+
+	tok := Token{
+		Type:     IDENT,
+		Literal:  "x",
+		Filename: "main.monkey",
+		Line:     3,
+		Column:   5,
+		Offset:   12,
+	}
+
+	pos := tok.Pos()
+
+	if pos.Filename != "main.monkey" {
+		t.Errorf("pos.Filename wrong. expected=%q, got=%q", "main.monkey", pos.Filename)
+	}
+
+	if pos.Line != 3 {
+		t.Errorf("pos.Line wrong. expected=%d, got=%d", 3, pos.Line)
+	}
+
+	if pos.Column != 5 {
+		t.Errorf("pos.Column wrong. expected=%d, got=%d", 5, pos.Column)
+	}
+
+	if pos.Offset != 12 {
+		t.Errorf("pos.Offset wrong. expected=%d, got=%d", 12, pos.Offset)
+	}
+}